@@ -0,0 +1,212 @@
+package appconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/appconfigdata"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// minRetryInterval e maxRetryInterval limitam a espera, crescente a cada falha seguida, antes de
+	// uma nova consulta depois de uma falha
+	minRetryInterval = time.Second
+	maxRetryInterval = 5 * time.Minute
+)
+
+type AppConfigResource interface {
+	StartConfigurationSession(input *appconfigdata.StartConfigurationSessionInput) (*appconfigdata.StartConfigurationSessionOutput, error)
+	GetLatestConfiguration(input *appconfigdata.GetLatestConfigurationInput) (*appconfigdata.GetLatestConfigurationOutput, error)
+}
+
+// AppConfigCloudContext implementa CloudContext para AppConfig, usando a API de sessões do AppConfig Data
+type AppConfigCloudContext struct {
+	svc AppConfigResource
+
+	mutex    sync.Mutex
+	sessions map[string]*configSession
+}
+
+// configSession guarda o token de consulta e a última configuração recebida de um perfil
+type configSession struct {
+	mutex       sync.Mutex
+	application string
+	environment string
+	profile     string
+
+	token    string
+	nextPoll time.Time
+	loaded   bool
+	value    interface{}
+
+	// failures conta as consultas seguidas que falharam e lastErr guarda o erro da última delas
+	failures int
+	lastErr  error
+}
+
+func NewAppConfigContext(sess *session.Session) *AppConfigCloudContext {
+	return &AppConfigCloudContext{
+		svc: appconfigdata.New(sess),
+	}
+}
+
+// GetValue obtém a configuração do perfil decodificada de acordo com o Content-Type (JSON, YAML ou texto).
+// A configuração fica em cache até o intervalo de consulta indicado pelo AppConfig; depois disso é
+// consultada novamente com o token da sessão, recebendo conteúdo apenas quando houver mudança.
+// Se a consulta falhar depois da primeira leitura, a última configuração recebida é mantida e a consulta
+// é repetida após uma espera crescente; o erro fica disponível em LastError
+func (ctx *AppConfigCloudContext) GetValue(application, environment, profile string) (interface{}, error) {
+	return ctx.session(application, environment, profile).latest(ctx.svc)
+}
+
+// LastError retorna o erro da última consulta do perfil, ou nil se ela teve sucesso; permite detectar
+// que GetValue está retornando uma configuração antiga porque o AppConfig não está respondendo
+func (ctx *AppConfigCloudContext) LastError(application, environment, profile string) error {
+	return ctx.session(application, environment, profile).lastError()
+}
+
+// Flags retorna o avaliador de feature flags do perfil informado
+func (ctx *AppConfigCloudContext) Flags(application, environment, profile string) *FeatureFlags {
+	return &FeatureFlags{session: ctx.session(application, environment, profile), svc: ctx.svc}
+}
+
+func (ctx *AppConfigCloudContext) session(application, environment, profile string) *configSession {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	id := application + "/" + environment + "/" + profile
+	if ctx.sessions == nil {
+		ctx.sessions = make(map[string]*configSession)
+	}
+	s, ok := ctx.sessions[id]
+	if !ok {
+		s = &configSession{application: application, environment: environment, profile: profile}
+		ctx.sessions[id] = s
+	}
+	return s
+}
+
+func (s *configSession) latest(svc AppConfigResource) (interface{}, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if time.Now().Before(s.nextPoll) {
+		if !s.loaded {
+			return nil, s.lastErr
+		}
+		return s.value, nil
+	}
+
+	err := s.poll(svc)
+	if err != nil && isExpiredSession(err) {
+		// o token expira após 24 horas sem consultas; uma nova sessão é iniciada
+		s.token = ""
+		err = s.poll(svc)
+	}
+	if err != nil {
+		// a próxima consulta aguarda uma espera crescente, evitando uma chamada ao AppConfig a cada leitura
+		s.failures++
+		s.lastErr = err
+		s.nextPoll = time.Now().Add(retryInterval(s.failures))
+		if s.loaded {
+			return s.value, nil
+		}
+		return nil, err
+	}
+
+	s.failures = 0
+	s.lastErr = nil
+	return s.value, nil
+}
+
+func (s *configSession) lastError() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.lastErr
+}
+
+// retryInterval dobra a espera a cada falha seguida, até maxRetryInterval
+func retryInterval(failures int) time.Duration {
+	interval := minRetryInterval
+	for i := 1; i < failures && interval < maxRetryInterval; i++ {
+		interval *= 2
+	}
+	return min(interval, maxRetryInterval)
+}
+
+func (s *configSession) poll(svc AppConfigResource) error {
+	if s.token == "" {
+		started, err := svc.StartConfigurationSession(&appconfigdata.StartConfigurationSessionInput{
+			ApplicationIdentifier:          aws.String(s.application),
+			EnvironmentIdentifier:          aws.String(s.environment),
+			ConfigurationProfileIdentifier: aws.String(s.profile),
+		})
+		if err != nil {
+			return fmt.Errorf("error when starting AppConfig session: %w", err)
+		}
+		s.token = aws.StringValue(started.InitialConfigurationToken)
+	}
+
+	result, err := svc.GetLatestConfiguration(&appconfigdata.GetLatestConfigurationInput{
+		ConfigurationToken: aws.String(s.token),
+	})
+	if err != nil {
+		return fmt.Errorf("error when obtaining AppConfig configuration: %w", err)
+	}
+
+	s.token = aws.StringValue(result.NextPollConfigurationToken)
+	s.nextPoll = time.Now().Add(time.Duration(aws.Int64Value(result.NextPollIntervalInSeconds)) * time.Second)
+
+	// conteúdo vazio indica que a configuração não mudou desde a última consulta
+	if len(result.Configuration) > 0 || !s.loaded {
+		value, err := decodeConfiguration(result.Configuration, aws.StringValue(result.ContentType))
+		if err != nil {
+			return err
+		}
+		s.value = value
+		s.loaded = true
+	}
+	return nil
+}
+
+// isExpiredSession identifica a recusa de um token expirado; os demais BadRequestException, como uma
+// aplicação, ambiente ou perfil inexistente, são devolvidos sem iniciar uma nova sessão
+func isExpiredSession(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) &&
+		awsErr.Code() == appconfigdata.ErrCodeBadRequestException &&
+		strings.Contains(strings.ToLower(awsErr.Message()), "expired")
+}
+
+func decodeConfiguration(content []byte, contentType string) (interface{}, error) {
+	if len(content) == 0 {
+		return nil, nil
+	}
+
+	mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	switch {
+	case mediaType == "application/json":
+		var value interface{}
+		if err := json.Unmarshal(content, &value); err != nil {
+			return nil, fmt.Errorf("error when decoding AppConfig JSON configuration: %w", err)
+		}
+		return value, nil
+	case strings.Contains(mediaType, "yaml"):
+		var value interface{}
+		if err := yaml.Unmarshal(content, &value); err != nil {
+			return nil, fmt.Errorf("error when decoding AppConfig YAML configuration: %w", err)
+		}
+		return value, nil
+	default:
+		return string(content), nil
+	}
+}
@@ -0,0 +1,186 @@
+package appconfig
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/appconfigdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock para AppConfig Data
+type mockAppConfigClient struct {
+	mock.Mock
+}
+
+func (m *mockAppConfigClient) StartConfigurationSession(input *appconfigdata.StartConfigurationSessionInput) (*appconfigdata.StartConfigurationSessionOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*appconfigdata.StartConfigurationSessionOutput), args.Error(1)
+}
+
+func (m *mockAppConfigClient) GetLatestConfiguration(input *appconfigdata.GetLatestConfigurationInput) (*appconfigdata.GetLatestConfigurationOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*appconfigdata.GetLatestConfigurationOutput), args.Error(1)
+}
+
+var (
+	mockAppConfig *mockAppConfigClient
+	ctx           *AppConfigCloudContext
+)
+
+func loadDefaultVariables() {
+	mockAppConfig = new(mockAppConfigClient)
+	ctx = &AppConfigCloudContext{
+		svc: mockAppConfig,
+	}
+}
+
+// expirePoll antecipa a próxima consulta de todas as sessões
+func expirePoll() {
+	for _, s := range ctx.sessions {
+		s.nextPoll = time.Now().Add(-time.Second)
+	}
+}
+
+func latestOutput(token, content string, interval int64) *appconfigdata.GetLatestConfigurationOutput {
+	return &appconfigdata.GetLatestConfigurationOutput{
+		Configuration:              []byte(content),
+		ContentType:                aws.String("application/json"),
+		NextPollConfigurationToken: aws.String(token),
+		NextPollIntervalInSeconds:  aws.Int64(interval),
+	}
+}
+
+func tokenIs(token string) interface{} {
+	return mock.MatchedBy(func(input *appconfigdata.GetLatestConfigurationInput) bool {
+		return aws.StringValue(input.ConfigurationToken) == token
+	})
+}
+
+func TestRetryInterval(t *testing.T) {
+	assert.Equal(t, time.Second, retryInterval(1))
+	assert.Equal(t, 4*time.Second, retryInterval(3))
+	assert.Equal(t, maxRetryInterval, retryInterval(100))
+}
+
+func TestAppConfigCloudContext_GetValue(t *testing.T) {
+	t.Run("Cache until the poll interval and keep content when unchanged", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockAppConfig.On("StartConfigurationSession", mock.MatchedBy(func(input *appconfigdata.StartConfigurationSessionInput) bool {
+			return aws.StringValue(input.ApplicationIdentifier) == "app" &&
+				aws.StringValue(input.EnvironmentIdentifier) == "prod" &&
+				aws.StringValue(input.ConfigurationProfileIdentifier) == "settings"
+		})).Return(&appconfigdata.StartConfigurationSessionOutput{InitialConfigurationToken: aws.String("t0")}, nil).Once()
+		mockAppConfig.On("GetLatestConfiguration", tokenIs("t0")).Return(latestOutput("t1", `{"limit":10}`, 60), nil).Once()
+		mockAppConfig.On("GetLatestConfiguration", tokenIs("t1")).Return(latestOutput("t2", "", 60), nil).Once()
+
+		for i := 0; i < 2; i++ {
+			value, err := ctx.GetValue("app", "prod", "settings")
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"limit": float64(10)}, value)
+		}
+		mockAppConfig.AssertNumberOfCalls(t, "GetLatestConfiguration", 1)
+
+		expirePoll()
+		value, err := ctx.GetValue("app", "prod", "settings")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"limit": float64(10)}, value)
+		mockAppConfig.AssertNumberOfCalls(t, "GetLatestConfiguration", 2)
+		mockAppConfig.AssertNumberOfCalls(t, "StartConfigurationSession", 1)
+	})
+
+	t.Run("Restart the session when the token expires", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockAppConfig.On("StartConfigurationSession", mock.Anything).Return(&appconfigdata.StartConfigurationSessionOutput{InitialConfigurationToken: aws.String("t0")}, nil).Once()
+		mockAppConfig.On("GetLatestConfiguration", tokenIs("t0")).Return(latestOutput("t1", `{"v":1}`, 60), nil).Once()
+		mockAppConfig.On("GetLatestConfiguration", tokenIs("t1")).Return((*appconfigdata.GetLatestConfigurationOutput)(nil),
+			awserr.New(appconfigdata.ErrCodeBadRequestException, "expired token", nil)).Once()
+		mockAppConfig.On("StartConfigurationSession", mock.Anything).Return(&appconfigdata.StartConfigurationSessionOutput{InitialConfigurationToken: aws.String("n0")}, nil).Once()
+		mockAppConfig.On("GetLatestConfiguration", tokenIs("n0")).Return(latestOutput("n1", `{"v":2}`, 60), nil).Once()
+
+		_, err := ctx.GetValue("app", "prod", "settings")
+		assert.NoError(t, err)
+
+		expirePoll()
+		value, err := ctx.GetValue("app", "prod", "settings")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"v": float64(2)}, value)
+	})
+
+	t.Run("Return other bad requests without restarting the session", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockAppConfig.On("StartConfigurationSession", mock.Anything).Return(&appconfigdata.StartConfigurationSessionOutput{InitialConfigurationToken: aws.String("t0")}, nil).Once()
+		mockAppConfig.On("GetLatestConfiguration", tokenIs("t0")).Return((*appconfigdata.GetLatestConfigurationOutput)(nil),
+			awserr.New(appconfigdata.ErrCodeBadRequestException, "invalid application", nil)).Once()
+
+		_, err := ctx.GetValue("app", "prod", "settings")
+
+		assert.ErrorContains(t, err, "invalid application")
+		mockAppConfig.AssertNumberOfCalls(t, "StartConfigurationSession", 1)
+	})
+
+	t.Run("Back off after failures and expose the last error", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockAppConfig.On("StartConfigurationSession", mock.Anything).Return(&appconfigdata.StartConfigurationSessionOutput{InitialConfigurationToken: aws.String("t0")}, nil).Once()
+		mockAppConfig.On("GetLatestConfiguration", tokenIs("t0")).Return(latestOutput("t1", `{"v":1}`, 60), nil).Once()
+		mockAppConfig.On("GetLatestConfiguration", tokenIs("t1")).Return((*appconfigdata.GetLatestConfigurationOutput)(nil),
+			errors.New("unavailable")).Once()
+		mockAppConfig.On("GetLatestConfiguration", tokenIs("t1")).Return(latestOutput("t2", "", 60), nil).Once()
+
+		_, err := ctx.GetValue("app", "prod", "settings")
+		assert.NoError(t, err)
+
+		expirePoll()
+		for i := 0; i < 3; i++ {
+			value, err := ctx.GetValue("app", "prod", "settings")
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"v": float64(1)}, value)
+		}
+		mockAppConfig.AssertNumberOfCalls(t, "GetLatestConfiguration", 2)
+		assert.ErrorContains(t, ctx.LastError("app", "prod", "settings"), "unavailable")
+		assert.ErrorContains(t, ctx.Flags("app", "prod", "settings").LastError(), "unavailable")
+
+		expirePoll()
+		_, err = ctx.GetValue("app", "prod", "settings")
+
+		assert.NoError(t, err)
+		assert.NoError(t, ctx.LastError("app", "prod", "settings"))
+	})
+
+	t.Run("Back off when the first read fails", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockAppConfig.On("StartConfigurationSession", mock.Anything).Return((*appconfigdata.StartConfigurationSessionOutput)(nil),
+			errors.New("denied")).Once()
+
+		for i := 0; i < 2; i++ {
+			_, err := ctx.GetValue("app", "prod", "settings")
+			assert.ErrorContains(t, err, "denied")
+		}
+		mockAppConfig.AssertNumberOfCalls(t, "StartConfigurationSession", 1)
+	})
+
+	t.Run("Decode YAML configuration", func(t *testing.T) {
+		loadDefaultVariables()
+
+		output := latestOutput("t1", "limit: 5\n", 60)
+		output.ContentType = aws.String("application/x-yaml")
+		mockAppConfig.On("StartConfigurationSession", mock.Anything).Return(&appconfigdata.StartConfigurationSessionOutput{InitialConfigurationToken: aws.String("t0")}, nil)
+		mockAppConfig.On("GetLatestConfiguration", mock.Anything).Return(output, nil)
+
+		value, err := ctx.GetValue("app", "prod", "settings")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"limit": 5}, value)
+	})
+}
@@ -0,0 +1,141 @@
+package appconfig
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// FeatureFlags avalia as feature flags de um perfil, consultando a configuração mais recente a cada
+// avaliação. IsEnabled e Attributes funcionam com perfis do tipo AWS.AppConfig.FeatureFlags.
+//
+// Variant exige um perfil freeform (AWS.Freeform) em JSON, já que o schema do tipo
+// AWS.AppConfig.FeatureFlags só aceita atributos de tipos simples e recusa "variants" e
+// "defaultVariant". O documento do perfil freeform mapeia o nome de cada flag para a sua definição,
+// que além do atributo "enabled" pode declarar variantes avaliadas localmente, na ordem declarada:
+//
+//	"theme": {
+//	  "enabled": true,
+//	  "defaultVariant": "light",
+//	  "variants": [
+//	    {"name": "dark", "when": {"tenant": ["acme", "globex"]}},
+//	    {"name": "beta", "percentage": 10, "key": "userId"}
+//	  ]
+//	}
+//
+// "when" exige que cada atributo informado seja igual ao valor, ou a um dos valores da lista;
+// "percentage" seleciona de forma estável a fração dos valores do atributo "key".
+//
+// Todas as variantes de uma flag usam o mesmo bucket para o mesmo valor, então os percentuais são
+// acumulados: como a primeira variante satisfeita vence, a variante i recebe p_i - p_(i-1) dos valores.
+// Para dividir 10% para "a" e 20% para "b", declare "a" com 10 e "b" com 30
+type FeatureFlags struct {
+	session *configSession
+	svc     AppConfigResource
+}
+
+// IsEnabled informa se a flag existe e está habilitada
+func (f *FeatureFlags) IsEnabled(flag string) (bool, error) {
+	definition, err := f.flag(flag)
+	if err != nil || definition == nil {
+		return false, err
+	}
+
+	enabled, _ := definition["enabled"].(bool)
+	return enabled, nil
+}
+
+// LastError retorna o erro da última consulta ao AppConfig, ou nil se ela teve sucesso
+func (f *FeatureFlags) LastError() error {
+	return f.session.lastError()
+}
+
+// Attributes retorna os atributos da flag; flags inexistentes retornam nil
+func (f *FeatureFlags) Attributes(flag string) (map[string]interface{}, error) {
+	return f.flag(flag)
+}
+
+// Variant retorna o nome da primeira variante cujas condições são satisfeitas pelos atributos; quando
+// nenhuma é satisfeita, retorna "defaultVariant". Flags inexistentes ou desabilitadas retornam ""
+func (f *FeatureFlags) Variant(flag string, attributes map[string]interface{}) (string, error) {
+	definition, err := f.flag(flag)
+	if err != nil || definition == nil {
+		return "", err
+	}
+	if enabled, _ := definition["enabled"].(bool); !enabled {
+		return "", nil
+	}
+
+	variants, _ := definition["variants"].([]interface{})
+	for _, item := range variants {
+		variant, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if matchesVariant(flag, variant, attributes) {
+			name, _ := variant["name"].(string)
+			return name, nil
+		}
+	}
+
+	defaultVariant, _ := definition["defaultVariant"].(string)
+	return defaultVariant, nil
+}
+
+func (f *FeatureFlags) flag(flag string) (map[string]interface{}, error) {
+	value, err := f.session.latest(f.svc)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	flags, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the AppConfig profile %s does not contain feature flags", f.session.profile)
+	}
+
+	definition, _ := flags[flag].(map[string]interface{})
+	return definition, nil
+}
+
+func matchesVariant(flag string, variant, attributes map[string]interface{}) bool {
+	if when, ok := variant["when"].(map[string]interface{}); ok {
+		for name, expected := range when {
+			if !matchesValue(attributes[name], expected) {
+				return false
+			}
+		}
+	}
+
+	if percentage, ok := variant["percentage"].(float64); ok {
+		key, _ := variant["key"].(string)
+		value, ok := attributes[key]
+		if !ok {
+			return false
+		}
+
+		// o bucket depende apenas da flag e do valor, mantendo a mesma variante entre avaliações
+		hash := fnv.New32a()
+		fmt.Fprintf(hash, "%s:%v", flag, value)
+		if float64(hash.Sum32()%10000) >= percentage*100 {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesValue(actual, expected interface{}) bool {
+	if actual == nil {
+		return false
+	}
+	if values, ok := expected.([]interface{}); ok {
+		for _, value := range values {
+			if fmt.Sprint(actual) == fmt.Sprint(value) {
+				return true
+			}
+		}
+		return false
+	}
+	return fmt.Sprint(actual) == fmt.Sprint(expected)
+}
@@ -0,0 +1,70 @@
+package appconfig
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/appconfigdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const flagsContent = `{
+  "checkout": {"enabled": true},
+  "legacy": {"enabled": false, "defaultVariant": "old"},
+  "theme": {
+    "enabled": true,
+    "defaultVariant": "light",
+    "variants": [
+      {"name": "dark", "when": {"tenant": ["acme", "globex"]}},
+      {"name": "beta", "percentage": 50, "key": "userId"}
+    ]
+  }
+}`
+
+func TestFeatureFlags(t *testing.T) {
+	loadDefaultVariables()
+
+	mockAppConfig.On("StartConfigurationSession", mock.Anything).Return(&appconfigdata.StartConfigurationSessionOutput{InitialConfigurationToken: aws.String("t0")}, nil)
+	mockAppConfig.On("GetLatestConfiguration", mock.Anything).Return(latestOutput("t1", flagsContent, 60), nil)
+
+	flags := ctx.Flags("app", "prod", "flags")
+
+	t.Run("IsEnabled", func(t *testing.T) {
+		enabled, err := flags.IsEnabled("checkout")
+		assert.NoError(t, err)
+		assert.True(t, enabled)
+
+		enabled, _ = flags.IsEnabled("legacy")
+		assert.False(t, enabled)
+
+		enabled, _ = flags.IsEnabled("missing")
+		assert.False(t, enabled)
+	})
+
+	t.Run("Variant by attribute match", func(t *testing.T) {
+		variant, err := flags.Variant("theme", map[string]interface{}{"tenant": "acme"})
+		assert.NoError(t, err)
+		assert.Equal(t, "dark", variant)
+
+		variant, _ = flags.Variant("legacy", nil)
+		assert.Equal(t, "", variant)
+	})
+
+	t.Run("Variant by stable percentage", func(t *testing.T) {
+		counts := map[string]int{}
+		for i := 0; i < 1000; i++ {
+			attributes := map[string]interface{}{"userId": fmt.Sprint(i)}
+			first, _ := flags.Variant("theme", attributes)
+			second, _ := flags.Variant("theme", attributes)
+			assert.Equal(t, first, second)
+			counts[first]++
+		}
+
+		assert.InDelta(t, 500, counts["beta"], 100)
+		assert.Equal(t, 1000, counts["beta"]+counts["light"])
+	})
+
+	mockAppConfig.AssertNumberOfCalls(t, "GetLatestConfiguration", 1)
+}
@@ -0,0 +1,79 @@
+package batch
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	// MaxEntries é o limite de entradas por chamada das APIs em lote do SQS, SNS e EventBridge
+	MaxEntries = 10
+
+	// MaxBytes é o limite de tamanho somado das entradas de uma chamada em lote
+	MaxBytes = 256 * 1024
+)
+
+// Failure descreve uma entrada que o serviço não aceitou na chamada em lote
+type Failure struct {
+	ID          string
+	Code        string
+	Message     string
+	SenderFault bool
+}
+
+// Result relaciona o ID de cada entrada aceita ao identificador atribuído pelo serviço e lista as falhas
+type Result struct {
+	Successful map[string]string
+	Failed     []Failure
+}
+
+// NewResult cria um Result vazio com capacidade para a quantidade de entradas informada
+func NewResult(size int) *Result {
+	return &Result{Successful: make(map[string]string, size)}
+}
+
+// Entry é uma entrada já convertida para a API, com o tamanho considerado no limite do lote
+type Entry[T any] struct {
+	Value T
+	Size  int
+}
+
+// Split agrupa as entradas, na ordem recebida, em lotes de até MaxEntries entradas e MaxBytes
+func Split[T any](entries []Entry[T]) [][]T {
+	var batches [][]T
+	var current []T
+	currentBytes := 0
+
+	for _, entry := range entries {
+		if len(current) == MaxEntries || currentBytes+entry.Size > MaxBytes {
+			batches = append(batches, current)
+			current = nil
+			currentBytes = 0
+		}
+		current = append(current, entry.Value)
+		currentBytes += entry.Size
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// ResolveIDs retorna o ID de cada entrada: o informado pelo chamador ou, quando vazio, a posição da
+// entrada. IDs repetidos são recusados, inclusive um ID informado igual à posição de outra entrada,
+// já que o serviço recusa o lote inteiro e o resultado deixaria de identificar cada entrada
+func ResolveIDs(ids []string) ([]string, error) {
+	resolved := make([]string, len(ids))
+	positions := make(map[string]int, len(ids))
+	for i, id := range ids {
+		if id == "" {
+			id = strconv.Itoa(i)
+		}
+		if previous, ok := positions[id]; ok {
+			return nil, fmt.Errorf("entries %d and %d have the same batch id %q", previous, i, id)
+		}
+		positions[id] = i
+		resolved[i] = id
+	}
+	return resolved, nil
+}
@@ -0,0 +1,57 @@
+package batch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	t.Run("Split by number of entries", func(t *testing.T) {
+		entries := make([]Entry[int], 25)
+		for i := range entries {
+			entries[i] = Entry[int]{Value: i, Size: 1}
+		}
+
+		batches := Split(entries)
+
+		assert.Len(t, batches, 3)
+		assert.Len(t, batches[0], MaxEntries)
+		assert.Equal(t, []int{20, 21, 22, 23, 24}, batches[2])
+	})
+
+	t.Run("Split by size", func(t *testing.T) {
+		batches := Split([]Entry[string]{
+			{Value: "a", Size: 200 * 1024},
+			{Value: "b", Size: 100 * 1024},
+			{Value: "c", Size: 100 * 1024},
+		})
+
+		assert.Equal(t, [][]string{{"a"}, {"b", "c"}}, batches)
+	})
+
+	t.Run("No entries", func(t *testing.T) {
+		assert.Empty(t, Split[int](nil))
+	})
+}
+
+func TestResolveIDs(t *testing.T) {
+	t.Run("Default to the position", func(t *testing.T) {
+		ids, err := ResolveIDs([]string{"", "order-1", ""})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"0", "order-1", "2"}, ids)
+	})
+
+	t.Run("Reject id that collides with a default id", func(t *testing.T) {
+		_, err := ResolveIDs([]string{"", "0"})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Reject duplicated ids", func(t *testing.T) {
+		_, err := ResolveIDs([]string{"a", "b", "a"})
+
+		assert.Error(t, err)
+	})
+}
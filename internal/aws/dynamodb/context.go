@@ -0,0 +1,107 @@
+package dynamodb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	dynamodbTable "github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// ErrConditionFailed indica que a condição de uma gravação condicional não foi satisfeita
+var ErrConditionFailed = errors.New("dynamodb condition check failed")
+
+type DynamoDBResource interface {
+	GetItem(input *dynamodbTable.GetItemInput) (*dynamodbTable.GetItemOutput, error)
+	PutItem(input *dynamodbTable.PutItemInput) (*dynamodbTable.PutItemOutput, error)
+	Query(input *dynamodbTable.QueryInput) (*dynamodbTable.QueryOutput, error)
+	BatchGetItem(input *dynamodbTable.BatchGetItemInput) (*dynamodbTable.BatchGetItemOutput, error)
+}
+
+// DynamoDBCloudContext implementa CloudContext para DynamoDB
+type DynamoDBCloudContext struct {
+	svc DynamoDBResource
+}
+
+func NewDynamoDBContext(sess *session.Session) *DynamoDBCloudContext {
+	return &DynamoDBCloudContext{
+		svc: dynamodbTable.New(sess),
+	}
+}
+
+// GetItem lê o item com a chave informada e o converte para out, usando as tags `dynamodbav` da struct;
+// retorna false quando o item não existe
+func (ctx *DynamoDBCloudContext) GetItem(tableName string, key interface{}, out interface{}, opts ...ReadOption) (bool, error) {
+	options := newReadOptions(opts)
+
+	keyValues, err := dynamodbattribute.MarshalMap(key)
+	if err != nil {
+		return false, fmt.Errorf("error when marshalling dynamodb key: %w", err)
+	}
+
+	input := &dynamodbTable.GetItemInput{
+		TableName:      aws.String(tableName),
+		Key:            keyValues,
+		ConsistentRead: aws.Bool(options.ConsistentRead),
+	}
+	if options.Projection != "" {
+		input.ProjectionExpression = aws.String(options.Projection)
+	}
+	if len(options.Names) > 0 {
+		input.ExpressionAttributeNames = aws.StringMap(options.Names)
+	}
+
+	result, err := ctx.svc.GetItem(input)
+	if err != nil {
+		return false, fmt.Errorf("error when getting dynamodb item: %w", err)
+	}
+	if len(result.Item) == 0 {
+		return false, nil
+	}
+
+	if err := dynamodbattribute.UnmarshalMap(result.Item, out); err != nil {
+		return false, fmt.Errorf("error when unmarshalling dynamodb item: %w", err)
+	}
+	return true, nil
+}
+
+// PutItem grava o item convertido a partir da struct; com WithCondition a gravação só ocorre se a
+// condição for verdadeira, retornando ErrConditionFailed caso contrário
+func (ctx *DynamoDBCloudContext) PutItem(tableName string, item interface{}, opts ...WriteOption) error {
+	options := newWriteOptions(opts)
+
+	itemValues, err := dynamodbattribute.MarshalMap(item)
+	if err != nil {
+		return fmt.Errorf("error when marshalling dynamodb item: %w", err)
+	}
+
+	input := &dynamodbTable.PutItemInput{
+		TableName: aws.String(tableName),
+		Item:      itemValues,
+	}
+	if options.Condition != "" {
+		input.ConditionExpression = aws.String(options.Condition)
+		if len(options.Names) > 0 {
+			input.ExpressionAttributeNames = aws.StringMap(options.Names)
+		}
+		if len(options.Values) > 0 {
+			values, err := dynamodbattribute.MarshalMap(options.Values)
+			if err != nil {
+				return fmt.Errorf("error when marshalling dynamodb condition values: %w", err)
+			}
+			input.ExpressionAttributeValues = values
+		}
+	}
+
+	if _, err := ctx.svc.PutItem(input); err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == dynamodbTable.ErrCodeConditionalCheckFailedException {
+			return ErrConditionFailed
+		}
+		return fmt.Errorf("error when putting dynamodb item: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package dynamodb
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock para DynamoDB
+type mockDynamoDBClient struct {
+	mock.Mock
+}
+
+func (m *mockDynamoDBClient) GetItem(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.GetItemOutput), args.Error(1)
+}
+
+func (m *mockDynamoDBClient) PutItem(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.PutItemOutput), args.Error(1)
+}
+
+func (m *mockDynamoDBClient) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.QueryOutput), args.Error(1)
+}
+
+func (m *mockDynamoDBClient) BatchGetItem(input *dynamodb.BatchGetItemInput) (*dynamodb.BatchGetItemOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.BatchGetItemOutput), args.Error(1)
+}
+
+type country struct {
+	Code string `dynamodbav:"code"`
+	Name string `dynamodbav:"name"`
+	Rank int    `dynamodbav:"rank,omitempty"`
+}
+
+type countryKey struct {
+	Code string `dynamodbav:"code"`
+}
+
+var (
+	mockDynamoDB *mockDynamoDBClient
+	ctx          *DynamoDBCloudContext
+)
+
+func loadDefaultVariables() {
+	mockDynamoDB = new(mockDynamoDBClient)
+	ctx = &DynamoDBCloudContext{
+		svc: mockDynamoDB,
+	}
+}
+
+func countryItem(code, name string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"code": {S: aws.String(code)},
+		"name": {S: aws.String(name)},
+	}
+}
+
+func TestDynamoDBCloudContext_GetItem(t *testing.T) {
+	t.Run("Get item into struct with consistent read", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockDynamoDB.On("GetItem", mock.MatchedBy(func(input *dynamodb.GetItemInput) bool {
+			return aws.BoolValue(input.ConsistentRead) && aws.StringValue(input.Key["code"].S) == "BR"
+		})).Return(&dynamodb.GetItemOutput{Item: countryItem("BR", "Brasil")}, nil)
+
+		var result country
+		found, err := ctx.GetItem("countries", countryKey{Code: "BR"}, &result, WithConsistentRead())
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, country{Code: "BR", Name: "Brasil"}, result)
+	})
+
+	t.Run("Missing item", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockDynamoDB.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+
+		var result country
+		found, err := ctx.GetItem("countries", countryKey{Code: "XX"}, &result)
+
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestDynamoDBCloudContext_PutItem(t *testing.T) {
+	t.Run("Conditional put", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockDynamoDB.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+			return aws.StringValue(input.ConditionExpression) == "attribute_not_exists(#pk)" &&
+				aws.StringValue(input.ExpressionAttributeNames["#pk"]) == "code" &&
+				input.ExpressionAttributeValues == nil &&
+				aws.StringValue(input.Item["name"].S) == "Brasil"
+		})).Return(&dynamodb.PutItemOutput{}, nil)
+
+		err := ctx.PutItem("countries", country{Code: "BR", Name: "Brasil"}, WithIfNotExists("code"))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Condition failed", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockDynamoDB.On("PutItem", mock.Anything).Return((*dynamodb.PutItemOutput)(nil),
+			awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "failed", nil))
+
+		err := ctx.PutItem("countries", country{Code: "BR"}, WithCondition("#r < :r", map[string]string{"#r": "rank"}, map[string]interface{}{":r": 3}))
+
+		assert.ErrorIs(t, err, ErrConditionFailed)
+	})
+}
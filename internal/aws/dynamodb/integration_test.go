@@ -0,0 +1,79 @@
+//go:build integration
+
+package dynamodb
+
+import (
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDynamoDBLocal executa o contexto contra o DynamoDB Local, por exemplo:
+//
+//	docker run -p 8000:8000 amazon/dynamodb-local
+//	DYNAMODB_ENDPOINT=http://localhost:8000 go test -tags integration ./internal/aws/dynamodb/
+func TestDynamoDBLocal(t *testing.T) {
+	endpoint := os.Getenv("DYNAMODB_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("DYNAMODB_ENDPOINT is not set")
+	}
+
+	sess := session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Endpoint:    aws.String(endpoint),
+		Credentials: credentials.NewStaticCredentials("local", "local", ""),
+	}))
+	client := dynamodb.New(sess)
+
+	tableName := "cec-integration-countries"
+	_, _ = client.DeleteTable(&dynamodb.DeleteTableInput{TableName: aws.String(tableName)})
+	_, err := client.CreateTable(&dynamodb.CreateTableInput{
+		TableName: aws.String(tableName),
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			{AttributeName: aws.String("code"), AttributeType: aws.String(dynamodb.ScalarAttributeTypeS)},
+			{AttributeName: aws.String("rank"), AttributeType: aws.String(dynamodb.ScalarAttributeTypeN)},
+		},
+		KeySchema: []*dynamodb.KeySchemaElement{
+			{AttributeName: aws.String("code"), KeyType: aws.String(dynamodb.KeyTypeHash)},
+			{AttributeName: aws.String("rank"), KeyType: aws.String(dynamodb.KeyTypeRange)},
+		},
+		BillingMode: aws.String(dynamodb.BillingModePayPerRequest),
+	})
+	require.NoError(t, err)
+	defer client.DeleteTable(&dynamodb.DeleteTableInput{TableName: aws.String(tableName)})
+
+	ctx := NewDynamoDBContext(sess)
+
+	for rank := 1; rank <= 3; rank++ {
+		require.NoError(t, ctx.PutItem(tableName, country{Code: "BR", Name: "Brasil", Rank: rank}))
+	}
+	err = ctx.PutItem(tableName, country{Code: "BR", Name: "Brasil", Rank: 1}, WithIfNotExists("code"))
+	assert.ErrorIs(t, err, ErrConditionFailed)
+
+	var item country
+	found, err := ctx.GetItem(tableName, map[string]interface{}{"code": "BR", "rank": 2}, &item, WithConsistentRead())
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 2, item.Rank)
+
+	it, err := ctx.Query(tableName, "code = :c", map[string]interface{}{":c": "BR"}, WithPageSize(1), WithDescending())
+	require.NoError(t, err)
+	var items []country
+	require.NoError(t, it.All(&items))
+	require.Len(t, items, 3)
+	assert.Equal(t, 3, items[0].Rank)
+
+	var batch []country
+	err = ctx.BatchGetItem(tableName, []interface{}{
+		map[string]interface{}{"code": "BR", "rank": 1},
+		map[string]interface{}{"code": "BR", "rank": 3},
+	}, &batch)
+	require.NoError(t, err)
+	assert.Len(t, batch, 2)
+}
@@ -0,0 +1,132 @@
+package dynamodb
+
+// ReadOption configura uma leitura individual de itens DynamoDB
+type ReadOption func(*ReadOptions)
+
+// ReadOptions reúne as configurações aplicadas em GetItem, Query e BatchGetItem
+type ReadOptions struct {
+	// ConsistentRead solicita leitura fortemente consistente; não é suportado em índices globais
+	ConsistentRead bool
+
+	// IndexName consulta um índice secundário em vez da tabela
+	IndexName string
+
+	// PageSize limita a quantidade de itens avaliados por página de Query
+	PageSize int64
+
+	// Descending percorre a chave de ordenação em ordem decrescente
+	Descending bool
+
+	// Filter é uma expressão aplicada aos itens depois da condição de chave
+	Filter string
+
+	// Projection restringe os atributos retornados
+	Projection string
+
+	// Names mapeia placeholders (#nome) usados nas expressões
+	Names map[string]string
+
+	// Values mapeia placeholders (:valor) adicionais usados no filtro
+	Values map[string]interface{}
+}
+
+// WithConsistentRead solicita leitura fortemente consistente
+func WithConsistentRead() ReadOption {
+	return func(o *ReadOptions) {
+		o.ConsistentRead = true
+	}
+}
+
+// WithIndex consulta o índice secundário informado
+func WithIndex(indexName string) ReadOption {
+	return func(o *ReadOptions) {
+		o.IndexName = indexName
+	}
+}
+
+// WithPageSize limita a quantidade de itens avaliados por página
+func WithPageSize(pageSize int64) ReadOption {
+	return func(o *ReadOptions) {
+		o.PageSize = pageSize
+	}
+}
+
+// WithDescending percorre a chave de ordenação em ordem decrescente
+func WithDescending() ReadOption {
+	return func(o *ReadOptions) {
+		o.Descending = true
+	}
+}
+
+// WithFilter aplica uma expressão de filtro aos itens; os placeholders são combinados aos da condição de chave
+func WithFilter(expression string, values map[string]interface{}) ReadOption {
+	return func(o *ReadOptions) {
+		o.Filter = expression
+		o.Values = values
+	}
+}
+
+// WithProjection restringe os atributos retornados
+func WithProjection(expression string) ReadOption {
+	return func(o *ReadOptions) {
+		o.Projection = expression
+	}
+}
+
+// WithNames define os placeholders de nomes de atributos (#nome) usados nas expressões
+func WithNames(names map[string]string) ReadOption {
+	return func(o *ReadOptions) {
+		o.Names = names
+	}
+}
+
+func newReadOptions(opts []ReadOption) *ReadOptions {
+	options := &ReadOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
+
+// WriteOption configura uma gravação individual de item DynamoDB
+type WriteOption func(*WriteOptions)
+
+// WriteOptions reúne as configurações aplicadas em PutItem
+type WriteOptions struct {
+	// Condition é a expressão que precisa ser verdadeira para a gravação ocorrer
+	Condition string
+
+	// Names mapeia placeholders (#nome) usados na condição
+	Names map[string]string
+
+	// Values mapeia placeholders (:valor) usados na condição
+	Values map[string]interface{}
+}
+
+// WithCondition grava o item apenas se a expressão for verdadeira; caso contrário retorna ErrConditionFailed
+func WithCondition(expression string, names map[string]string, values map[string]interface{}) WriteOption {
+	return func(o *WriteOptions) {
+		o.Condition = expression
+		o.Names = names
+		o.Values = values
+	}
+}
+
+// WithIfNotExists grava o item apenas se não houver outro com a mesma chave primária. A condição é avaliada
+// sobre o item com a chave completa (partição e, se houver, ordenação): em tabelas com chave composta, itens
+// com a mesma partição e outra chave de ordenação não impedem a gravação
+func WithIfNotExists(partitionKey string) WriteOption {
+	return WithCondition("attribute_not_exists(#pk)", map[string]string{"#pk": partitionKey}, nil)
+}
+
+func newWriteOptions(opts []WriteOption) *WriteOptions {
+	options := &WriteOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
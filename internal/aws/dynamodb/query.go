@@ -0,0 +1,195 @@
+package dynamodb
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	dynamodbTable "github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+const (
+	// maxBatchGetKeys é o limite de chaves aceito por chamada BatchGetItem
+	maxBatchGetKeys = 100
+
+	maxBatchAttempts = 5
+)
+
+// batchRetryDelay é o intervalo inicial entre novas tentativas das chaves não processadas
+var batchRetryDelay = 50 * time.Millisecond
+
+// QueryIterator percorre os itens de uma consulta, buscando novas páginas sob demanda
+type QueryIterator struct {
+	svc   DynamoDBResource
+	input *dynamodbTable.QueryInput
+	items []map[string]*dynamodbTable.AttributeValue
+	done  bool
+}
+
+// Query consulta os itens que satisfazem a condição de chave; os valores são referenciados na expressão
+// como placeholders (:valor) e as páginas são buscadas conforme o iterador avança
+func (ctx *DynamoDBCloudContext) Query(tableName, keyCondition string, values map[string]interface{}, opts ...ReadOption) (*QueryIterator, error) {
+	options := newReadOptions(opts)
+
+	allValues := make(map[string]interface{}, len(values)+len(options.Values))
+	for name, value := range values {
+		allValues[name] = value
+	}
+	for name, value := range options.Values {
+		allValues[name] = value
+	}
+	expressionValues, err := dynamodbattribute.MarshalMap(allValues)
+	if err != nil {
+		return nil, fmt.Errorf("error when marshalling dynamodb query values: %w", err)
+	}
+
+	input := &dynamodbTable.QueryInput{
+		TableName:              aws.String(tableName),
+		KeyConditionExpression: aws.String(keyCondition),
+		ConsistentRead:         aws.Bool(options.ConsistentRead),
+		ScanIndexForward:       aws.Bool(!options.Descending),
+	}
+	if len(expressionValues) > 0 {
+		input.ExpressionAttributeValues = expressionValues
+	}
+	if options.IndexName != "" {
+		input.IndexName = aws.String(options.IndexName)
+	}
+	if options.PageSize > 0 {
+		input.Limit = aws.Int64(options.PageSize)
+	}
+	if options.Filter != "" {
+		input.FilterExpression = aws.String(options.Filter)
+	}
+	if options.Projection != "" {
+		input.ProjectionExpression = aws.String(options.Projection)
+	}
+	if len(options.Names) > 0 {
+		input.ExpressionAttributeNames = aws.StringMap(options.Names)
+	}
+
+	return &QueryIterator{svc: ctx.svc, input: input}, nil
+}
+
+// Next converte o próximo item para v; retorna io.EOF quando não houver mais itens
+func (it *QueryIterator) Next(v interface{}) error {
+	for len(it.items) == 0 {
+		if it.done {
+			return io.EOF
+		}
+		if err := it.fetch(); err != nil {
+			return err
+		}
+	}
+
+	item := it.items[0]
+	it.items = it.items[1:]
+	if err := dynamodbattribute.UnmarshalMap(item, v); err != nil {
+		return fmt.Errorf("error when unmarshalling dynamodb item: %w", err)
+	}
+	return nil
+}
+
+// All converte todos os itens restantes para out, que deve ser um ponteiro para slice
+func (it *QueryIterator) All(out interface{}) error {
+	var items []map[string]*dynamodbTable.AttributeValue
+	for {
+		items = append(items, it.items...)
+		it.items = nil
+		if it.done {
+			break
+		}
+		if err := it.fetch(); err != nil {
+			return err
+		}
+	}
+
+	if err := dynamodbattribute.UnmarshalListOfMaps(items, out); err != nil {
+		return fmt.Errorf("error when unmarshalling dynamodb items: %w", err)
+	}
+	return nil
+}
+
+func (it *QueryIterator) fetch() error {
+	result, err := it.svc.Query(it.input)
+	if err != nil {
+		return fmt.Errorf("error when querying dynamodb table: %w", err)
+	}
+
+	it.items = result.Items
+	it.input.ExclusiveStartKey = result.LastEvaluatedKey
+	it.done = len(result.LastEvaluatedKey) == 0
+	return nil
+}
+
+// BatchGetItem lê os itens das chaves informadas, em lotes de 100, e os converte para out, que deve ser
+// um ponteiro para slice; chaves não processadas pelo DynamoDB são reenviadas com espera crescente.
+// A ordem dos itens retornados não corresponde à ordem das chaves
+func (ctx *DynamoDBCloudContext) BatchGetItem(tableName string, keys []interface{}, out interface{}, opts ...ReadOption) error {
+	options := newReadOptions(opts)
+
+	var items []map[string]*dynamodbTable.AttributeValue
+	for start := 0; start < len(keys); start += maxBatchGetKeys {
+		end := start + maxBatchGetKeys
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		keysAndAttributes := &dynamodbTable.KeysAndAttributes{
+			ConsistentRead: aws.Bool(options.ConsistentRead),
+		}
+		if options.Projection != "" {
+			keysAndAttributes.ProjectionExpression = aws.String(options.Projection)
+		}
+		if len(options.Names) > 0 {
+			keysAndAttributes.ExpressionAttributeNames = aws.StringMap(options.Names)
+		}
+		for _, key := range keys[start:end] {
+			keyValues, err := dynamodbattribute.MarshalMap(key)
+			if err != nil {
+				return fmt.Errorf("error when marshalling dynamodb key: %w", err)
+			}
+			keysAndAttributes.Keys = append(keysAndAttributes.Keys, keyValues)
+		}
+
+		batchItems, err := ctx.batchGet(tableName, keysAndAttributes)
+		if err != nil {
+			return err
+		}
+		items = append(items, batchItems...)
+	}
+
+	if err := dynamodbattribute.UnmarshalListOfMaps(items, out); err != nil {
+		return fmt.Errorf("error when unmarshalling dynamodb items: %w", err)
+	}
+	return nil
+}
+
+func (ctx *DynamoDBCloudContext) batchGet(tableName string, request *dynamodbTable.KeysAndAttributes) ([]map[string]*dynamodbTable.AttributeValue, error) {
+	var items []map[string]*dynamodbTable.AttributeValue
+	delay := batchRetryDelay
+
+	for attempt := 1; ; attempt++ {
+		result, err := ctx.svc.BatchGetItem(&dynamodbTable.BatchGetItemInput{
+			RequestItems: map[string]*dynamodbTable.KeysAndAttributes{tableName: request},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error when batch getting dynamodb items: %w", err)
+		}
+		items = append(items, result.Responses[tableName]...)
+
+		unprocessed, ok := result.UnprocessedKeys[tableName]
+		if !ok || len(unprocessed.Keys) == 0 {
+			return items, nil
+		}
+		if attempt == maxBatchAttempts {
+			return nil, fmt.Errorf("dynamodb left %d keys unprocessed after %d attempts", len(unprocessed.Keys), attempt)
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+		request = unprocessed
+	}
+}
@@ -0,0 +1,112 @@
+package dynamodb
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDynamoDBCloudContext_Query(t *testing.T) {
+	t.Run("Iterate across pages", func(t *testing.T) {
+		loadDefaultVariables()
+
+		lastKey := map[string]*dynamodb.AttributeValue{"code": {S: aws.String("AR")}}
+		mockDynamoDB.On("Query", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+			return input.ExclusiveStartKey == nil
+		})).Return(&dynamodb.QueryOutput{
+			Items:            []map[string]*dynamodb.AttributeValue{countryItem("AR", "Argentina")},
+			LastEvaluatedKey: lastKey,
+		}, nil).Once()
+		mockDynamoDB.On("Query", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+			return input.ExclusiveStartKey != nil
+		})).Return(&dynamodb.QueryOutput{
+			Items: []map[string]*dynamodb.AttributeValue{countryItem("BR", "Brasil")},
+		}, nil).Once()
+
+		it, err := ctx.Query("countries", "#c = :c", map[string]interface{}{":c": "south-america"},
+			WithIndex("by-continent"), WithNames(map[string]string{"#c": "continent"}), WithPageSize(1))
+		assert.NoError(t, err)
+
+		var names []string
+		for {
+			var item country
+			if err := it.Next(&item); err == io.EOF {
+				break
+			} else if !assert.NoError(t, err) {
+				return
+			}
+			names = append(names, item.Name)
+		}
+
+		assert.Equal(t, []string{"Argentina", "Brasil"}, names)
+		mockDynamoDB.AssertNumberOfCalls(t, "Query", 2)
+	})
+
+	t.Run("Collect all items", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockDynamoDB.On("Query", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+			return !aws.BoolValue(input.ScanIndexForward) && aws.StringValue(input.FilterExpression) == "#r > :min"
+		})).Return(&dynamodb.QueryOutput{
+			Items: []map[string]*dynamodb.AttributeValue{countryItem("BR", "Brasil"), countryItem("AR", "Argentina")},
+		}, nil)
+
+		it, err := ctx.Query("countries", "code = :c", map[string]interface{}{":c": "BR"},
+			WithDescending(), WithFilter("#r > :min", map[string]interface{}{":min": 1}), WithNames(map[string]string{"#r": "rank"}))
+		assert.NoError(t, err)
+
+		var result []country
+		assert.NoError(t, it.All(&result))
+		assert.Len(t, result, 2)
+	})
+}
+
+func TestDynamoDBCloudContext_BatchGetItem(t *testing.T) {
+	t.Run("Chunk keys and retry unprocessed keys", func(t *testing.T) {
+		loadDefaultVariables()
+		batchRetryDelay = 0
+
+		keys := make([]interface{}, 0, 150)
+		for i := 0; i < 150; i++ {
+			keys = append(keys, countryKey{Code: fmt.Sprintf("C%03d", i)})
+		}
+
+		unprocessed := &dynamodb.KeysAndAttributes{
+			Keys: []map[string]*dynamodb.AttributeValue{{"code": {S: aws.String("C099")}}},
+		}
+		mockDynamoDB.On("BatchGetItem", mock.MatchedBy(func(input *dynamodb.BatchGetItemInput) bool {
+			return len(input.RequestItems["countries"].Keys) == 100
+		})).Return(&dynamodb.BatchGetItemOutput{
+			Responses: map[string][]map[string]*dynamodb.AttributeValue{
+				"countries": {countryItem("C000", "first")},
+			},
+			UnprocessedKeys: map[string]*dynamodb.KeysAndAttributes{"countries": unprocessed},
+		}, nil).Once()
+		mockDynamoDB.On("BatchGetItem", mock.MatchedBy(func(input *dynamodb.BatchGetItemInput) bool {
+			return len(input.RequestItems["countries"].Keys) == 1
+		})).Return(&dynamodb.BatchGetItemOutput{
+			Responses: map[string][]map[string]*dynamodb.AttributeValue{
+				"countries": {countryItem("C099", "retried")},
+			},
+		}, nil).Once()
+		mockDynamoDB.On("BatchGetItem", mock.MatchedBy(func(input *dynamodb.BatchGetItemInput) bool {
+			return len(input.RequestItems["countries"].Keys) == 50
+		})).Return(&dynamodb.BatchGetItemOutput{
+			Responses: map[string][]map[string]*dynamodb.AttributeValue{
+				"countries": {countryItem("C149", "last")},
+			},
+		}, nil).Once()
+
+		var result []country
+		err := ctx.BatchGetItem("countries", keys, &result)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 3)
+		mockDynamoDB.AssertNumberOfCalls(t, "BatchGetItem", 3)
+	})
+}
@@ -0,0 +1,285 @@
+package eventbridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	eventBus "github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/raywall/cloud-easy-connector/internal/aws/batch"
+)
+
+const defaultMaxAttempts = 3
+
+// retryDelay é o intervalo inicial entre as novas tentativas dos eventos que falharam
+var retryDelay = 100 * time.Millisecond
+
+type EventBridgeResource interface {
+	PutEvents(input *eventBus.PutEventsInput) (*eventBus.PutEventsOutput, error)
+}
+
+// EventBridgeCloudContext implementa CloudContext para EventBridge
+type EventBridgeCloudContext struct {
+	svc EventBridgeResource
+}
+
+func NewEventBridgeContext(sess *session.Session) *EventBridgeCloudContext {
+	return &EventBridgeCloudContext{
+		svc: eventBus.New(sess),
+	}
+}
+
+// Event é um evento de domínio a ser publicado no barramento
+type Event struct {
+	Source string
+
+	// DetailType pode ser omitido quando Detail implementar a interface Detail
+	DetailType string
+
+	// Detail é convertido para JSON, exceto quando já for string, []byte ou json.RawMessage
+	Detail interface{}
+
+	// EventBusName substitui o barramento definido por WithEventBus
+	EventBusName string
+
+	Resources   []string
+	Time        time.Time
+	TraceHeader string
+}
+
+// Detail é implementado pelos tipos de detalhe que correspondem a um schema do barramento, associando
+// o tipo Go ao DetailType publicado
+type Detail interface {
+	DetailType() string
+}
+
+// NewEvent cria o evento com o DetailType definido pelo tipo do detalhe
+func NewEvent[T Detail](source string, detail T) Event {
+	return Event{
+		Source:     source,
+		DetailType: detail.DetailType(),
+		Detail:     detail,
+	}
+}
+
+// EntryError descreve a falha de um evento individual
+type EntryError struct {
+	Code    string
+	Message string
+
+	// Err é a falha da chamada à API, quando o lote inteiro do evento não pôde ser enviado
+	Err error
+}
+
+func (e *EntryError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *EntryError) Unwrap() error {
+	return e.Err
+}
+
+// PutEventsError reúne as falhas individuais de PutEvents, indexadas pela posição do evento
+type PutEventsError struct {
+	Errors map[int]*EntryError
+}
+
+func (e *PutEventsError) Error() string {
+	positions := make([]int, 0, len(e.Errors))
+	for position := range e.Errors {
+		positions = append(positions, position)
+	}
+	sort.Ints(positions)
+
+	messages := make([]string, 0, len(positions))
+	for _, position := range positions {
+		messages = append(messages, fmt.Sprintf("%d: %v", position, e.Errors[position]))
+	}
+	return fmt.Sprintf("error when putting %d EventBridge events: %s", len(positions), strings.Join(messages, "; "))
+}
+
+// PutOption configura uma publicação de eventos
+type PutOption func(*PutOptions)
+
+// PutOptions reúne as configurações aplicadas em PutEvents
+type PutOptions struct {
+	// EventBus é o barramento usado pelos eventos sem EventBusName; o padrão é o barramento default
+	EventBus string
+
+	// MaxAttempts limita as tentativas de cada evento; o padrão é 3
+	MaxAttempts int
+}
+
+// WithEventBus publica os eventos sem EventBusName no barramento informado
+func WithEventBus(name string) PutOption {
+	return func(o *PutOptions) {
+		o.EventBus = name
+	}
+}
+
+// WithMaxAttempts limita as tentativas de publicação de cada evento
+func WithMaxAttempts(attempts int) PutOption {
+	return func(o *PutOptions) {
+		o.MaxAttempts = attempts
+	}
+}
+
+func newPutOptions(opts []PutOption) *PutOptions {
+	options := &PutOptions{MaxAttempts: defaultMaxAttempts}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
+
+// pendingEntry associa a entrada da API à posição do evento na chamada original
+type pendingEntry struct {
+	position int
+	entry    *eventBus.PutEventsRequestEntry
+	size     int
+}
+
+// PutEvents publica os eventos em lotes de até 10 entradas e 256 KB, repetindo com espera crescente
+// somente as entradas que o EventBridge reportar como falha e as dos lotes cuja chamada falhou. Retorna
+// os EventIds na mesma ordem dos eventos; eventos que não foram publicados ficam vazios e são
+// detalhados em um *PutEventsError
+func (ctx *EventBridgeCloudContext) PutEvents(events []Event, opts ...PutOption) ([]string, error) {
+	options := newPutOptions(opts)
+	eventIDs := make([]string, len(events))
+	errs := make(map[int]*EntryError)
+
+	pending := make([]batch.Entry[pendingEntry], 0, len(events))
+	for i, event := range events {
+		entry, size, err := requestEntry(event, options)
+		if err == nil && size > batch.MaxBytes {
+			err = fmt.Errorf("event has %d bytes and exceeds the EventBridge limit of %d bytes", size, batch.MaxBytes)
+		}
+		if err != nil {
+			errs[i] = &EntryError{Code: "InvalidEvent", Message: err.Error()}
+			continue
+		}
+		pending = append(pending, batch.Entry[pendingEntry]{Value: pendingEntry{position: i, entry: entry, size: size}, Size: size})
+	}
+
+	delay := retryDelay
+	for attempt := 1; len(pending) > 0; attempt++ {
+		var failed []batch.Entry[pendingEntry]
+		for _, items := range batch.Split(pending) {
+			entries := make([]*eventBus.PutEventsRequestEntry, 0, len(items))
+			for _, item := range items {
+				entries = append(entries, item.entry)
+			}
+
+			result, err := ctx.svc.PutEvents(&eventBus.PutEventsInput{Entries: entries})
+			if err != nil {
+				// a falha da chamada afeta somente os eventos deste lote; os demais lotes seguem sendo enviados
+				for _, item := range items {
+					errs[item.position] = &EntryError{Code: "RequestFailed", Message: err.Error(), Err: err}
+					failed = append(failed, batch.Entry[pendingEntry]{Value: item, Size: item.size})
+				}
+				continue
+			}
+
+			// as entradas da resposta seguem a mesma ordem das entradas enviadas
+			for i, item := range items {
+				var resultEntry *eventBus.PutEventsResultEntry
+				if i < len(result.Entries) {
+					resultEntry = result.Entries[i]
+				}
+				if resultEntry != nil && aws.StringValue(resultEntry.ErrorCode) == "" {
+					eventIDs[item.position] = aws.StringValue(resultEntry.EventId)
+					delete(errs, item.position)
+					continue
+				}
+
+				entryErr := &EntryError{Code: "MissingResult", Message: "EventBridge did not report a result for the event"}
+				if resultEntry != nil {
+					entryErr = &EntryError{
+						Code:    aws.StringValue(resultEntry.ErrorCode),
+						Message: aws.StringValue(resultEntry.ErrorMessage),
+					}
+				}
+				errs[item.position] = entryErr
+				failed = append(failed, batch.Entry[pendingEntry]{Value: item, Size: item.size})
+			}
+		}
+
+		if len(failed) == 0 || attempt >= options.MaxAttempts {
+			break
+		}
+		time.Sleep(delay)
+		delay *= 2
+		pending = failed
+	}
+
+	if len(errs) > 0 {
+		return eventIDs, &PutEventsError{Errors: errs}
+	}
+	return eventIDs, nil
+}
+
+func requestEntry(event Event, options *PutOptions) (*eventBus.PutEventsRequestEntry, int, error) {
+	detail, err := encodeDetail(event.Detail)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error when encoding EventBridge detail: %w", err)
+	}
+
+	detailType := event.DetailType
+	if typed, ok := event.Detail.(Detail); ok && detailType == "" {
+		detailType = typed.DetailType()
+	}
+
+	entry := &eventBus.PutEventsRequestEntry{
+		Source:     aws.String(event.Source),
+		DetailType: aws.String(detailType),
+		Detail:     aws.String(detail),
+	}
+	busName := event.EventBusName
+	if busName == "" {
+		busName = options.EventBus
+	}
+	if busName != "" {
+		entry.EventBusName = aws.String(busName)
+	}
+	if len(event.Resources) > 0 {
+		entry.Resources = aws.StringSlice(event.Resources)
+	}
+	if !event.Time.IsZero() {
+		entry.Time = aws.Time(event.Time)
+	}
+	if event.TraceHeader != "" {
+		entry.TraceHeader = aws.String(event.TraceHeader)
+	}
+
+	// tamanho calculado como na documentação do PutEvents, com 14 bytes reservados para Time
+	size := 14 + len(event.Source) + len(detailType) + len(detail)
+	for _, resource := range event.Resources {
+		size += len(resource)
+	}
+	return entry, size, nil
+}
+
+func encodeDetail(detail interface{}) (string, error) {
+	switch value := detail.(type) {
+	case nil:
+		return "{}", nil
+	case string:
+		return value, nil
+	case []byte:
+		return string(value), nil
+	case json.RawMessage:
+		return string(value), nil
+	}
+
+	body, err := json.Marshal(detail)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
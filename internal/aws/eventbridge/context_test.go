@@ -0,0 +1,184 @@
+package eventbridge
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock para EventBridge
+type mockEventBridgeClient struct {
+	mock.Mock
+}
+
+func (m *mockEventBridgeClient) PutEvents(input *eventbridge.PutEventsInput) (*eventbridge.PutEventsOutput, error) {
+	args := m.Called(input)
+	if respond, ok := args.Get(0).(func(*eventbridge.PutEventsInput) *eventbridge.PutEventsOutput); ok {
+		return respond(input), args.Error(1)
+	}
+	return args.Get(0).(*eventbridge.PutEventsOutput), args.Error(1)
+}
+
+type orderCreated struct {
+	OrderID int     `json:"orderId"`
+	Amount  float64 `json:"amount"`
+}
+
+func (orderCreated) DetailType() string {
+	return "OrderCreated"
+}
+
+var (
+	mockEventBridge *mockEventBridgeClient
+	ctx             *EventBridgeCloudContext
+)
+
+func loadDefaultVariables() {
+	mockEventBridge = new(mockEventBridgeClient)
+	ctx = &EventBridgeCloudContext{
+		svc: mockEventBridge,
+	}
+	retryDelay = 0
+}
+
+// successfulOutput responde com um EventId para cada entrada recebida
+func successfulOutput(input *eventbridge.PutEventsInput) *eventbridge.PutEventsOutput {
+	output := &eventbridge.PutEventsOutput{FailedEntryCount: aws.Int64(0)}
+	for _, entry := range input.Entries {
+		output.Entries = append(output.Entries, &eventbridge.PutEventsResultEntry{EventId: aws.String("id-" + aws.StringValue(entry.Detail))})
+	}
+	return output
+}
+
+func TestEventBridgeCloudContext_PutEvents(t *testing.T) {
+	t.Run("Encode detail and batch by ten", func(t *testing.T) {
+		loadDefaultVariables()
+
+		var batches []int
+		mockEventBridge.On("PutEvents", mock.Anything).Return(func(input *eventbridge.PutEventsInput) *eventbridge.PutEventsOutput {
+			batches = append(batches, len(input.Entries))
+			assert.Equal(t, "orders-bus", aws.StringValue(input.Entries[0].EventBusName))
+			return successfulOutput(input)
+		}, nil)
+
+		events := make([]Event, 0, 12)
+		for i := 0; i < 12; i++ {
+			events = append(events, Event{Source: "orders", DetailType: "OrderCreated", Detail: orderCreated{OrderID: i, Amount: 1.5}})
+		}
+
+		ids, err := ctx.PutEvents(events, WithEventBus("orders-bus"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{10, 2}, batches)
+		assert.Equal(t, `id-{"orderId":11,"amount":1.5}`, ids[11])
+	})
+
+	t.Run("Retry only failed entries", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockEventBridge.On("PutEvents", mock.MatchedBy(func(input *eventbridge.PutEventsInput) bool {
+			return len(input.Entries) == 3
+		})).Return(&eventbridge.PutEventsOutput{
+			FailedEntryCount: aws.Int64(1),
+			Entries: []*eventbridge.PutEventsResultEntry{
+				{EventId: aws.String("a")},
+				{ErrorCode: aws.String("ThrottlingException"), ErrorMessage: aws.String("slow down")},
+				{EventId: aws.String("c")},
+			},
+		}, nil).Once()
+		mockEventBridge.On("PutEvents", mock.MatchedBy(func(input *eventbridge.PutEventsInput) bool {
+			return len(input.Entries) == 1 && aws.StringValue(input.Entries[0].Detail) == `"b"`
+		})).Return(&eventbridge.PutEventsOutput{
+			Entries: []*eventbridge.PutEventsResultEntry{{EventId: aws.String("b")}},
+		}, nil).Once()
+
+		ids, err := ctx.PutEvents([]Event{
+			{Source: "s", DetailType: "t", Detail: quoted("a")},
+			{Source: "s", DetailType: "t", Detail: quoted("b")},
+			{Source: "s", DetailType: "t", Detail: quoted("c")},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, ids)
+		mockEventBridge.AssertNumberOfCalls(t, "PutEvents", 2)
+	})
+
+	t.Run("Surface per-entry errors after max attempts", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockEventBridge.On("PutEvents", mock.Anything).Return(&eventbridge.PutEventsOutput{
+			FailedEntryCount: aws.Int64(1),
+			Entries: []*eventbridge.PutEventsResultEntry{
+				{ErrorCode: aws.String("InternalFailure"), ErrorMessage: aws.String("oops")},
+			},
+		}, nil)
+
+		ids, err := ctx.PutEvents([]Event{
+			{Source: "s", DetailType: "t", Detail: func() {}},
+			{Source: "s", DetailType: "t", Detail: "{}"},
+		}, WithMaxAttempts(2))
+
+		var putErr *PutEventsError
+		assert.True(t, errors.As(err, &putErr))
+		assert.Equal(t, "InvalidEvent", putErr.Errors[0].Code)
+		assert.Equal(t, "InternalFailure", putErr.Errors[1].Code)
+		assert.Equal(t, []string{"", ""}, ids)
+		mockEventBridge.AssertNumberOfCalls(t, "PutEvents", 2)
+	})
+}
+
+func TestEventBridgeCloudContext_PutEventsPartialFailure(t *testing.T) {
+	t.Run("Keep sending after a failed request", func(t *testing.T) {
+		loadDefaultVariables()
+
+		requestErr := errors.New("connection reset")
+		mockEventBridge.On("PutEvents", mock.MatchedBy(func(input *eventbridge.PutEventsInput) bool {
+			return len(input.Entries) == 10
+		})).Return((*eventbridge.PutEventsOutput)(nil), requestErr)
+		mockEventBridge.On("PutEvents", mock.MatchedBy(func(input *eventbridge.PutEventsInput) bool {
+			return len(input.Entries) == 2
+		})).Return(successfulOutput, nil)
+
+		events := make([]Event, 0, 12)
+		for i := 0; i < 12; i++ {
+			events = append(events, NewEvent("orders", orderCreated{OrderID: i}))
+		}
+
+		ids, err := ctx.PutEvents(events, WithMaxAttempts(1))
+
+		var putErr *PutEventsError
+		assert.True(t, errors.As(err, &putErr))
+		assert.Len(t, putErr.Errors, 10)
+		assert.Equal(t, "RequestFailed", putErr.Errors[0].Code)
+		assert.ErrorIs(t, putErr.Errors[9], requestErr)
+		assert.Empty(t, ids[9])
+		assert.Equal(t, `id-{"orderId":10,"amount":0}`, ids[10])
+		assert.Equal(t, `id-{"orderId":11,"amount":0}`, ids[11])
+	})
+}
+
+func TestNewEvent(t *testing.T) {
+	loadDefaultVariables()
+
+	var input *eventbridge.PutEventsInput
+	mockEventBridge.On("PutEvents", mock.Anything).Run(func(args mock.Arguments) {
+		input = args.Get(0).(*eventbridge.PutEventsInput)
+	}).Return(successfulOutput, nil)
+
+	event := NewEvent("orders", orderCreated{OrderID: 1})
+	assert.Equal(t, "OrderCreated", event.DetailType)
+
+	_, err := ctx.PutEvents([]Event{{Source: "orders", Detail: orderCreated{OrderID: 2}}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "OrderCreated", aws.StringValue(input.Entries[0].DetailType))
+}
+
+func quoted(value string) string {
+	return fmt.Sprintf("%q", value)
+}
@@ -0,0 +1,105 @@
+package kinesis
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+)
+
+// kplMagic identifica um registro agregado no formato do Kinesis Producer Library (KPL)
+var kplMagic = []byte{0xF3, 0x89, 0x9A, 0xC2}
+
+// Campos do protobuf AggregatedRecord e Record do KPL, já combinados com o wire type
+const (
+	aggregatedPartitionKeyTable = 1<<3 | 2
+	aggregatedRecords           = 3<<3 | 2
+	recordPartitionKeyIndex     = 1<<3 | 0
+	recordData                  = 3<<3 | 2
+)
+
+// aggregate acumula registros com a mesma chave de partição em um único registro do Kinesis, no
+// formato de agregação do KPL que o KCL e as bibliotecas de desagregação reconhecem
+type aggregate struct {
+	partitionKey string
+	members      []*record
+
+	// protoSize é o tamanho do protobuf AggregatedRecord com os registros atuais
+	protoSize int
+}
+
+func newAggregate(partitionKey string) *aggregate {
+	return &aggregate{
+		partitionKey: partitionKey,
+		protoSize:    fieldSize(len(partitionKey)),
+	}
+}
+
+// size retorna o tamanho do registro agregado com os registros atuais, incluindo a chave de partição
+// enviada junto com ele, que o Kinesis conta nos limites de registro e de lote
+func (a *aggregate) size() int {
+	return len(kplMagic) + a.protoSize + md5.Size + len(a.partitionKey)
+}
+
+// sizeWith retorna o tamanho do registro agregado caso data seja acrescentado
+func (a *aggregate) sizeWith(data []byte) int {
+	return a.size() + fieldSize(recordSize(len(data)))
+}
+
+func (a *aggregate) add(r *record) {
+	a.members = append(a.members, r)
+	a.protoSize += fieldSize(recordSize(len(r.data)))
+}
+
+// record converte o agregado no registro enviado ao Kinesis; um agregado com um único registro é
+// enviado sem o envelope do KPL
+func (a *aggregate) record() *record {
+	if len(a.members) == 1 {
+		return a.members[0]
+	}
+
+	proto := make([]byte, 0, a.protoSize)
+	proto = binary.AppendUvarint(proto, aggregatedPartitionKeyTable)
+	proto = binary.AppendUvarint(proto, uint64(len(a.partitionKey)))
+	proto = append(proto, a.partitionKey...)
+
+	size := 0
+	for _, member := range a.members {
+		proto = binary.AppendUvarint(proto, aggregatedRecords)
+		proto = binary.AppendUvarint(proto, uint64(recordSize(len(member.data))))
+		proto = binary.AppendUvarint(proto, recordPartitionKeyIndex)
+		proto = binary.AppendUvarint(proto, 0)
+		proto = binary.AppendUvarint(proto, recordData)
+		proto = binary.AppendUvarint(proto, uint64(len(member.data)))
+		proto = append(proto, member.data...)
+		size += member.size
+	}
+
+	checksum := md5.Sum(proto)
+	data := make([]byte, 0, len(kplMagic)+len(proto)+md5.Size)
+	data = append(append(append(data, kplMagic...), proto...), checksum[:]...)
+
+	return &record{
+		partitionKey: a.partitionKey,
+		data:         data,
+		size:         size,
+		members:      a.members,
+	}
+}
+
+// recordSize é o tamanho do protobuf Record com partition_key_index igual a zero
+func recordSize(dataLength int) int {
+	return 1 + uvarintSize(0) + fieldSize(dataLength)
+}
+
+// fieldSize é o tamanho de um campo length-delimited de tag de um byte
+func fieldSize(length int) int {
+	return 1 + uvarintSize(uint64(length)) + length
+}
+
+func uvarintSize(value uint64) int {
+	size := 1
+	for value >= 0x80 {
+		value >>= 7
+		size++
+	}
+	return size
+}
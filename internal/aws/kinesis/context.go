@@ -0,0 +1,129 @@
+package kinesis
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/firehose"
+	kinesisStream "github.com/aws/aws-sdk-go/service/kinesis"
+)
+
+const (
+	// limites do PutRecords do Kinesis Data Streams
+	kinesisMaxBatchRecords = 500
+	kinesisMaxBatchBytes   = 5 * 1024 * 1024
+	kinesisMaxRecordBytes  = 1024 * 1024
+
+	// limites do PutRecordBatch do Firehose
+	firehoseMaxBatchRecords = 500
+	firehoseMaxBatchBytes   = 4 * 1024 * 1024
+	firehoseMaxRecordBytes  = 1000 * 1024
+)
+
+type KinesisResource interface {
+	PutRecords(input *kinesisStream.PutRecordsInput) (*kinesisStream.PutRecordsOutput, error)
+}
+
+type FirehoseResource interface {
+	PutRecordBatch(input *firehose.PutRecordBatchInput) (*firehose.PutRecordBatchOutput, error)
+}
+
+// KinesisCloudContext implementa CloudContext para Kinesis Data Streams e Firehose
+type KinesisCloudContext struct {
+	svc      KinesisResource
+	firehose FirehoseResource
+}
+
+func NewKinesisContext(sess *session.Session) *KinesisCloudContext {
+	return &KinesisCloudContext{
+		svc:      kinesisStream.New(sess),
+		firehose: firehose.New(sess),
+	}
+}
+
+// NewStreamProducer cria um Producer que grava no stream do Kinesis Data Streams informado
+func (ctx *KinesisCloudContext) NewStreamProducer(streamName string, opts ...ProducerOption) (*Producer, error) {
+	sink := &streamSink{svc: ctx.svc, streamName: streamName}
+	return newProducer(sink, kinesisMaxBatchRecords, kinesisMaxBatchBytes, kinesisMaxRecordBytes, opts)
+}
+
+// NewFirehoseProducer cria um Producer que grava no delivery stream do Firehose informado;
+// chaves de partição não são usadas pelo Firehose
+func (ctx *KinesisCloudContext) NewFirehoseProducer(deliveryStreamName string, opts ...ProducerOption) (*Producer, error) {
+	sink := &firehoseSink{svc: ctx.firehose, deliveryStreamName: deliveryStreamName}
+	return newProducer(sink, firehoseMaxBatchRecords, firehoseMaxBatchBytes, firehoseMaxRecordBytes, opts)
+}
+
+// recordSink envia um lote de registros e informa, por posição, os registros que falharam
+type recordSink interface {
+	put(records []*record) (map[int]*RecordError, error)
+	usesPartitionKey() bool
+}
+
+type streamSink struct {
+	svc        KinesisResource
+	streamName string
+}
+
+func (s *streamSink) put(records []*record) (map[int]*RecordError, error) {
+	entries := make([]*kinesisStream.PutRecordsRequestEntry, 0, len(records))
+	for _, r := range records {
+		entries = append(entries, &kinesisStream.PutRecordsRequestEntry{
+			Data:         r.data,
+			PartitionKey: aws.String(r.partitionKey),
+		})
+	}
+
+	result, err := s.svc.PutRecords(&kinesisStream.PutRecordsInput{
+		StreamName: aws.String(s.streamName),
+		Records:    entries,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when putting Kinesis records: %w", err)
+	}
+
+	failures := make(map[int]*RecordError)
+	for i, entry := range result.Records {
+		if code := aws.StringValue(entry.ErrorCode); code != "" {
+			failures[i] = &RecordError{Code: code, Message: aws.StringValue(entry.ErrorMessage)}
+		}
+	}
+	return failures, nil
+}
+
+func (s *streamSink) usesPartitionKey() bool {
+	return true
+}
+
+type firehoseSink struct {
+	svc                FirehoseResource
+	deliveryStreamName string
+}
+
+func (s *firehoseSink) put(records []*record) (map[int]*RecordError, error) {
+	entries := make([]*firehose.Record, 0, len(records))
+	for _, r := range records {
+		entries = append(entries, &firehose.Record{Data: r.data})
+	}
+
+	result, err := s.svc.PutRecordBatch(&firehose.PutRecordBatchInput{
+		DeliveryStreamName: aws.String(s.deliveryStreamName),
+		Records:            entries,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when putting Firehose records: %w", err)
+	}
+
+	failures := make(map[int]*RecordError)
+	for i, entry := range result.RequestResponses {
+		if code := aws.StringValue(entry.ErrorCode); code != "" {
+			failures[i] = &RecordError{Code: code, Message: aws.StringValue(entry.ErrorMessage)}
+		}
+	}
+	return failures, nil
+}
+
+func (s *firehoseSink) usesPartitionKey() bool {
+	return false
+}
@@ -0,0 +1,546 @@
+package kinesis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrProducerClosed indica uma gravação em um Producer já encerrado
+var ErrProducerClosed = errors.New("stream producer is closed")
+
+// maxPartitionKeyLength é o tamanho máximo, em caracteres Unicode, da chave de partição do Kinesis
+const maxPartitionKeyLength = 256
+
+// retryDelay é o intervalo inicial entre as novas tentativas dos registros que falharam
+var retryDelay = 100 * time.Millisecond
+
+// PartitionKeyFunc escolhe a chave de partição de um registro gravado com Put
+type PartitionKeyFunc func(data []byte) string
+
+// RandomPartitionKey distribui os registros aleatoriamente entre os shards
+func RandomPartitionKey() PartitionKeyFunc {
+	return func([]byte) string {
+		return strconv.FormatUint(rand.Uint64(), 36)
+	}
+}
+
+// FixedPartitionKey grava todos os registros no mesmo shard; a ordem de entrega não é garantida,
+// já que os registros repetidos após uma falha parcial são entregues depois dos seguintes
+func FixedPartitionKey(key string) PartitionKeyFunc {
+	return func([]byte) string {
+		return key
+	}
+}
+
+// HashPartitionKey usa o hash SHA-256 do conteúdo, levando registros iguais ao mesmo shard
+func HashPartitionKey() PartitionKeyFunc {
+	return func(data []byte) string {
+		digest := sha256.Sum256(data)
+		return hex.EncodeToString(digest[:])
+	}
+}
+
+// JSONFieldPartitionKey usa o valor de um campo do documento JSON, mantendo no mesmo shard os
+// registros da mesma entidade; registros sem o campo recebem uma chave aleatória, e valores com
+// mais de 256 caracteres são recusados por Put
+func JSONFieldPartitionKey(field string) PartitionKeyFunc {
+	random := RandomPartitionKey()
+	return func(data []byte) string {
+		var document map[string]interface{}
+		if err := json.Unmarshal(data, &document); err == nil {
+			if value, ok := document[field]; ok && value != nil {
+				return fmt.Sprint(value)
+			}
+		}
+		return random(data)
+	}
+}
+
+// RecordError descreve a falha de um registro reportada pelo Kinesis ou Firehose
+type RecordError struct {
+	Code    string
+	Message string
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// FailedRecord é um registro que não foi entregue depois de todas as tentativas
+type FailedRecord struct {
+	PartitionKey string
+	Data         []byte
+	Err          error
+}
+
+// DeliveryError reúne os registros que não foram entregues desde o último Flush
+type DeliveryError struct {
+	Records []FailedRecord
+}
+
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("error when delivering %d stream records: %v", len(e.Records), e.Records[0].Err)
+}
+
+// ProducerOption configura um Producer
+type ProducerOption func(*ProducerOptions)
+
+// ProducerOptions reúne as configurações de um Producer
+type ProducerOptions struct {
+	// FlushInterval é o tempo máximo que um registro aguarda no buffer; o padrão é 1 segundo
+	FlushInterval time.Duration
+
+	// MaxBufferedBytes limita a memória usada pelos registros ainda não entregues; quando atingido,
+	// Put bloqueia até haver espaço. O padrão é 16 MB
+	MaxBufferedBytes int
+
+	// MaxAttempts limita as tentativas de entrega de cada registro; o padrão é 5
+	MaxAttempts int
+
+	// PartitionKey escolhe a chave de partição dos registros gravados com Put; o padrão é RandomPartitionKey
+	PartitionKey PartitionKeyFunc
+
+	// Delimiter é acrescentado ao final de cada registro, como o "\n" esperado pelo Firehose em NDJSON
+	Delimiter []byte
+
+	// Aggregate agrupa os registros com a mesma chave de partição em registros agregados no formato
+	// do KPL, que os consumidores precisam desagregar (o KCL faz isso automaticamente); disponível
+	// somente para o Kinesis Data Streams
+	Aggregate bool
+
+	// OnError recebe cada registro que não foi entregue, além de ele ser informado no próximo Flush
+	OnError func(record FailedRecord)
+}
+
+// WithFlushInterval define o tempo máximo que um registro aguarda no buffer
+func WithFlushInterval(interval time.Duration) ProducerOption {
+	return func(o *ProducerOptions) {
+		o.FlushInterval = interval
+	}
+}
+
+// WithMaxBufferedBytes limita a memória usada pelos registros ainda não entregues
+func WithMaxBufferedBytes(maxBytes int) ProducerOption {
+	return func(o *ProducerOptions) {
+		o.MaxBufferedBytes = maxBytes
+	}
+}
+
+// WithMaxAttempts limita as tentativas de entrega de cada registro
+func WithMaxAttempts(attempts int) ProducerOption {
+	return func(o *ProducerOptions) {
+		o.MaxAttempts = attempts
+	}
+}
+
+// WithPartitionKey define a estratégia de chave de partição dos registros gravados com Put
+func WithPartitionKey(strategy PartitionKeyFunc) ProducerOption {
+	return func(o *ProducerOptions) {
+		o.PartitionKey = strategy
+	}
+}
+
+// WithDelimiter acrescenta o delimitador ao final de cada registro
+func WithDelimiter(delimiter []byte) ProducerOption {
+	return func(o *ProducerOptions) {
+		o.Delimiter = delimiter
+	}
+}
+
+// WithAggregation agrupa os registros com a mesma chave de partição no formato de agregação do KPL,
+// reduzindo a quantidade de registros cobrados e consumidos do limite de registros por shard
+func WithAggregation() ProducerOption {
+	return func(o *ProducerOptions) {
+		o.Aggregate = true
+	}
+}
+
+// WithRecordErrorHandler recebe cada registro que não foi entregue
+func WithRecordErrorHandler(onError func(record FailedRecord)) ProducerOption {
+	return func(o *ProducerOptions) {
+		o.OnError = onError
+	}
+}
+
+// record é um registro aguardando entrega
+type record struct {
+	partitionKey string
+	data         []byte
+	size         int
+
+	// members são os registros contidos em um registro agregado no formato do KPL
+	members []*record
+}
+
+// Producer acumula registros em memória e os entrega em lotes, quando o lote atinge o limite de
+// registros ou bytes da API, ou a cada FlushInterval. Os registros são agrupados em chamadas
+// PutRecords/PutRecordBatch e, com WithAggregation, também agregados no formato do KPL
+type Producer struct {
+	sink            recordSink
+	options         *ProducerOptions
+	maxBatchRecords int
+	maxBatchBytes   int
+	maxRecordBytes  int
+
+	mutex        sync.Mutex
+	pending      []*record
+	pendingBytes int
+	usedBytes    int
+	changed      chan struct{}
+	failed       []FailedRecord
+	closed       bool
+
+	trigger chan struct{}
+	flush   chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newProducer(sink recordSink, maxBatchRecords, maxBatchBytes, maxRecordBytes int, opts []ProducerOption) (*Producer, error) {
+	options := &ProducerOptions{
+		FlushInterval:    time.Second,
+		MaxBufferedBytes: 16 * 1024 * 1024,
+		MaxAttempts:      5,
+		PartitionKey:     RandomPartitionKey(),
+		OnError:          func(FailedRecord) {},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	if options.FlushInterval <= 0 || options.MaxBufferedBytes <= 0 || options.MaxAttempts <= 0 {
+		return nil, errors.New("invalid stream producer options")
+	}
+	if options.Aggregate && !sink.usesPartitionKey() {
+		return nil, errors.New("KPL aggregation is only supported by Kinesis Data Streams")
+	}
+
+	p := &Producer{
+		sink:            sink,
+		options:         options,
+		maxBatchRecords: maxBatchRecords,
+		maxBatchBytes:   maxBatchBytes,
+		maxRecordBytes:  maxRecordBytes,
+		changed:         make(chan struct{}),
+		trigger:         make(chan struct{}, 1),
+		flush:           make(chan struct{}, 1),
+		stop:            make(chan struct{}),
+		done:            make(chan struct{}),
+	}
+	go p.run()
+	return p, nil
+}
+
+// Put grava o registro usando a estratégia de chave de partição configurada; bloqueia enquanto o
+// buffer estiver cheio, até haver espaço ou ctx ser cancelado
+func (p *Producer) Put(ctx context.Context, data []byte) error {
+	partitionKey := ""
+	if p.sink.usesPartitionKey() {
+		partitionKey = p.options.PartitionKey(data)
+	}
+	return p.PutWithKey(ctx, partitionKey, data)
+}
+
+// PutWithKey grava o registro com a chave de partição informada, ignorada pelo Firehose
+func (p *Producer) PutWithKey(ctx context.Context, partitionKey string, data []byte) error {
+	if p.isClosed() {
+		return ErrProducerClosed
+	}
+
+	payload := data
+	if len(p.options.Delimiter) > 0 {
+		payload = make([]byte, 0, len(data)+len(p.options.Delimiter))
+		payload = append(append(payload, data...), p.options.Delimiter...)
+	}
+
+	r := &record{partitionKey: partitionKey, data: payload, size: len(payload)}
+	if p.sink.usesPartitionKey() {
+		// Uma chave inválida faria o Kinesis recusar o PutRecords inteiro, e não só este registro
+		if length := utf8.RuneCountInString(partitionKey); length < 1 || length > maxPartitionKeyLength {
+			return fmt.Errorf("the partition key must have between 1 and %d characters, got %d", maxPartitionKeyLength, length)
+		}
+		r.size += len(partitionKey)
+	}
+	if r.size > p.maxRecordBytes || r.size > p.options.MaxBufferedBytes {
+		return fmt.Errorf("record has %d bytes and exceeds the limit of %d bytes", r.size, min(p.maxRecordBytes, p.options.MaxBufferedBytes))
+	}
+
+	for {
+		p.mutex.Lock()
+		if p.closed {
+			p.mutex.Unlock()
+			return ErrProducerClosed
+		}
+		if p.usedBytes+r.size <= p.options.MaxBufferedBytes {
+			p.pending = append(p.pending, r)
+			p.pendingBytes += r.size
+			p.usedBytes += r.size
+			full := len(p.pending) >= p.maxBatchRecords || p.pendingBytes >= p.maxBatchBytes
+			p.mutex.Unlock()
+
+			if full {
+				signal(p.trigger)
+			}
+			return nil
+		}
+		changed := p.changed
+		p.mutex.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Flush entrega todos os registros do buffer e aguarda a conclusão; os registros que não puderam
+// ser entregues desde o último Flush são informados em um *DeliveryError
+func (p *Producer) Flush(ctx context.Context) error {
+	for {
+		signal(p.flush)
+
+		p.mutex.Lock()
+		if p.usedBytes == 0 {
+			failed := p.failed
+			p.failed = nil
+			p.mutex.Unlock()
+
+			if len(failed) > 0 {
+				return &DeliveryError{Records: failed}
+			}
+			return nil
+		}
+		changed := p.changed
+		p.mutex.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Close recusa novas gravações, entrega os registros restantes e encerra o Producer; se ctx for
+// cancelado antes da entrega, retorna o erro do contexto sem aguardar os registros em andamento
+func (p *Producer) Close(ctx context.Context) error {
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return ErrProducerClosed
+	}
+	p.closed = true
+	p.mutex.Unlock()
+
+	err := p.Flush(ctx)
+	close(p.stop)
+	if ctx.Err() == nil {
+		<-p.done
+	}
+	return err
+}
+
+func (p *Producer) isClosed() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.closed
+}
+
+func (p *Producer) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.options.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.trigger:
+			p.send(false)
+		case <-p.flush:
+			p.send(true)
+		case <-ticker.C:
+			p.send(true)
+		case <-p.stop:
+			p.send(true)
+			return
+		}
+	}
+}
+
+// send entrega os lotes completos do buffer e, com force, também o último lote parcial
+func (p *Producer) send(force bool) {
+	for {
+		batch, batchBytes := p.nextBatch(force)
+		if len(batch) == 0 {
+			return
+		}
+		p.deliver(batch)
+
+		p.mutex.Lock()
+		p.usedBytes -= batchBytes
+		close(p.changed)
+		p.changed = make(chan struct{})
+		p.mutex.Unlock()
+	}
+}
+
+func (p *Producer) nextBatch(force bool) ([]*record, int) {
+	if p.options.Aggregate {
+		aggregates, batchBytes := p.nextAggregates(force)
+		batch := make([]*record, 0, len(aggregates))
+		for _, a := range aggregates {
+			batch = append(batch, a.record())
+		}
+		return batch, batchBytes
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	count, batchBytes := 0, 0
+	for count < len(p.pending) && count < p.maxBatchRecords && batchBytes+p.pending[count].size <= p.maxBatchBytes {
+		batchBytes += p.pending[count].size
+		count++
+	}
+	full := count == p.maxBatchRecords || count < len(p.pending)
+	if count == 0 || (!force && !full) {
+		return nil, 0
+	}
+
+	batch := p.pending[:count:count]
+	p.pending = p.pending[count:]
+	p.pendingBytes -= batchBytes
+	return batch, batchBytes
+}
+
+// nextAggregates agrupa os registros do buffer, na ordem de gravação, em agregados por chave de
+// partição, respeitando o limite de tamanho de cada registro e os limites de registros e bytes do
+// lote; retorna também o tamanho somado dos registros originais consumidos do buffer
+func (p *Producer) nextAggregates(force bool) ([]*aggregate, int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var aggregates []*aggregate
+	open := make(map[string]*aggregate)
+	count, batchBytes, consumedBytes := 0, 0, 0
+
+	for count < len(p.pending) {
+		r := p.pending[count]
+
+		if a, ok := open[r.partitionKey]; ok {
+			// a chave de partição já está contada no agregado, que é enviado com uma única chave
+			grown := a.sizeWith(r.data) - a.size()
+			if a.size()+grown <= p.maxRecordBytes {
+				if batchBytes+grown > p.maxBatchBytes {
+					break
+				}
+				a.add(r)
+				batchBytes += grown
+				consumedBytes += r.size
+				count++
+				continue
+			}
+		}
+
+		a := newAggregate(r.partitionKey)
+		size := a.sizeWith(r.data)
+		if size > p.maxRecordBytes {
+			// o envelope do KPL não cabe junto com o registro, que segue sem agregação
+			size = r.size
+			delete(open, r.partitionKey)
+		} else {
+			open[r.partitionKey] = a
+		}
+		if len(aggregates) == p.maxBatchRecords || batchBytes+size > p.maxBatchBytes {
+			break
+		}
+		a.add(r)
+		aggregates = append(aggregates, a)
+		batchBytes += size
+		consumedBytes += r.size
+		count++
+	}
+
+	full := len(aggregates) == p.maxBatchRecords || count < len(p.pending)
+	if count == 0 || (!force && !full) {
+		return nil, 0
+	}
+
+	p.pending = p.pending[count:]
+	p.pendingBytes -= consumedBytes
+	return aggregates, consumedBytes
+}
+
+// deliver envia o lote, repetindo com espera crescente somente os registros que falharam
+func (p *Producer) deliver(batch []*record) {
+	delay := retryDelay
+	for attempt := 1; ; attempt++ {
+		failures, err := p.sink.put(batch)
+
+		var retry []*record
+		errs := make(map[*record]error)
+		for i, r := range batch {
+			if err != nil {
+				errs[r] = err
+			} else if failure, ok := failures[i]; ok {
+				errs[r] = failure
+			} else {
+				continue
+			}
+			retry = append(retry, r)
+		}
+
+		if len(retry) == 0 {
+			return
+		}
+		if attempt >= p.options.MaxAttempts {
+			p.fail(retry, errs)
+			return
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+		batch = retry
+	}
+}
+
+func (p *Producer) fail(records []*record, errs map[*record]error) {
+	failed := make([]FailedRecord, 0, len(records))
+	for _, r := range records {
+		// a falha de um registro agregado é informada para cada registro original contido nele
+		members := r.members
+		if len(members) == 0 {
+			members = []*record{r}
+		}
+		for _, member := range members {
+			failed = append(failed, FailedRecord{PartitionKey: member.partitionKey, Data: member.data, Err: errs[r]})
+		}
+	}
+
+	p.mutex.Lock()
+	p.failed = append(p.failed, failed...)
+	p.mutex.Unlock()
+
+	for _, record := range failed {
+		p.options.OnError(record)
+	}
+}
+
+// signal notifica o canal sem bloquear quando já houver uma notificação pendente
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,329 @@
+package kinesis
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/firehose"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock para Kinesis
+type mockKinesisClient struct {
+	mock.Mock
+}
+
+func (m *mockKinesisClient) PutRecords(input *kinesis.PutRecordsInput) (*kinesis.PutRecordsOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*kinesis.PutRecordsOutput), args.Error(1)
+}
+
+// Mock para Firehose
+type mockFirehoseClient struct {
+	mock.Mock
+}
+
+func (m *mockFirehoseClient) PutRecordBatch(input *firehose.PutRecordBatchInput) (*firehose.PutRecordBatchOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*firehose.PutRecordBatchOutput), args.Error(1)
+}
+
+var (
+	mockKinesis  *mockKinesisClient
+	mockFirehose *mockFirehoseClient
+	ctx          *KinesisCloudContext
+)
+
+func loadDefaultVariables() {
+	mockKinesis = new(mockKinesisClient)
+	mockFirehose = new(mockFirehoseClient)
+	ctx = &KinesisCloudContext{
+		svc:      mockKinesis,
+		firehose: mockFirehose,
+	}
+	retryDelay = 0
+}
+
+func TestProducer_Kinesis(t *testing.T) {
+	t.Run("Retry only partially failed records", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockKinesis.On("PutRecords", mock.MatchedBy(func(input *kinesis.PutRecordsInput) bool {
+			return len(input.Records) == 3
+		})).Return(&kinesis.PutRecordsOutput{
+			FailedRecordCount: aws.Int64(1),
+			Records: []*kinesis.PutRecordsResultEntry{
+				{SequenceNumber: aws.String("1")},
+				{ErrorCode: aws.String("ProvisionedThroughputExceededException")},
+				{SequenceNumber: aws.String("3")},
+			},
+		}, nil).Once()
+		mockKinesis.On("PutRecords", mock.MatchedBy(func(input *kinesis.PutRecordsInput) bool {
+			return len(input.Records) == 1 && string(input.Records[0].Data) == `{"user":"b"}` &&
+				aws.StringValue(input.Records[0].PartitionKey) == "b"
+		})).Return(&kinesis.PutRecordsOutput{
+			Records: []*kinesis.PutRecordsResultEntry{{SequenceNumber: aws.String("2")}},
+		}, nil).Once()
+
+		producer, err := ctx.NewStreamProducer("events", WithPartitionKey(JSONFieldPartitionKey("user")), WithFlushInterval(time.Hour))
+		assert.NoError(t, err)
+
+		for _, user := range []string{"a", "b", "c"} {
+			assert.NoError(t, producer.Put(context.Background(), []byte(`{"user":"`+user+`"}`)))
+		}
+
+		assert.NoError(t, producer.Flush(context.Background()))
+		assert.NoError(t, producer.Close(context.Background()))
+		mockKinesis.AssertNumberOfCalls(t, "PutRecords", 2)
+	})
+
+	t.Run("Flush on interval", func(t *testing.T) {
+		loadDefaultVariables()
+
+		sent := make(chan struct{})
+		mockKinesis.On("PutRecords", mock.Anything).Run(func(mock.Arguments) {
+			close(sent)
+		}).Return(&kinesis.PutRecordsOutput{Records: []*kinesis.PutRecordsResultEntry{{}}}, nil).Once()
+
+		producer, _ := ctx.NewStreamProducer("events", WithFlushInterval(10*time.Millisecond))
+		assert.NoError(t, producer.PutWithKey(context.Background(), "key", []byte("data")))
+
+		select {
+		case <-sent:
+		case <-time.After(time.Second):
+			t.Fatal("records were not flushed on interval")
+		}
+		assert.NoError(t, producer.Close(context.Background()))
+	})
+
+	t.Run("Backpressure blocks Put while the buffer is full", func(t *testing.T) {
+		loadDefaultVariables()
+
+		release := make(chan struct{})
+		mockKinesis.On("PutRecords", mock.Anything).Run(func(mock.Arguments) {
+			<-release
+		}).Return(&kinesis.PutRecordsOutput{Records: []*kinesis.PutRecordsResultEntry{{}}}, nil)
+
+		producer, _ := ctx.NewStreamProducer("events", WithMaxBufferedBytes(10), WithFlushInterval(time.Millisecond))
+		assert.NoError(t, producer.PutWithKey(context.Background(), "k", []byte("12345678")))
+
+		timeout, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := producer.PutWithKey(timeout, "k", []byte("12345678"))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		close(release)
+		assert.NoError(t, producer.PutWithKey(context.Background(), "k", []byte("12345678")))
+		assert.NoError(t, producer.Close(context.Background()))
+		assert.ErrorIs(t, producer.Put(context.Background(), []byte("late")), ErrProducerClosed)
+	})
+
+	t.Run("Reject partition keys outside the Kinesis limits", func(t *testing.T) {
+		loadDefaultVariables()
+
+		producer, _ := ctx.NewStreamProducer("events", WithPartitionKey(JSONFieldPartitionKey("user")), WithFlushInterval(time.Hour))
+
+		assert.Error(t, producer.PutWithKey(context.Background(), "", []byte("data")))
+		assert.Error(t, producer.PutWithKey(context.Background(), strings.Repeat("k", 257), []byte("data")))
+		assert.Error(t, producer.Put(context.Background(), []byte(`{"user":"`+strings.Repeat("u", 300)+`"}`)))
+		assert.NoError(t, producer.Close(context.Background()))
+		mockKinesis.AssertNotCalled(t, "PutRecords", mock.Anything)
+	})
+}
+
+// decodeAggregated extrai a tabela de chaves e os dados de um registro agregado no formato do KPL
+func decodeAggregated(t *testing.T, data []byte) ([]string, [][]byte) {
+	assert.True(t, bytes.HasPrefix(data, kplMagic))
+	proto := data[len(kplMagic) : len(data)-md5.Size]
+	checksum := md5.Sum(proto)
+	assert.Equal(t, checksum[:], data[len(data)-md5.Size:])
+
+	var keys []string
+	var records [][]byte
+	for len(proto) > 0 {
+		tag, n := binary.Uvarint(proto)
+		length, m := binary.Uvarint(proto[n:])
+		field := proto[n+m : n+m+int(length)]
+		proto = proto[n+m+int(length):]
+
+		switch tag {
+		case aggregatedPartitionKeyTable:
+			keys = append(keys, string(field))
+		case aggregatedRecords:
+			for len(field) > 0 {
+				recordTag, n := binary.Uvarint(field)
+				value, m := binary.Uvarint(field[n:])
+				if recordTag == recordPartitionKeyIndex {
+					assert.Equal(t, uint64(0), value)
+					field = field[n+m:]
+					continue
+				}
+				records = append(records, field[n+m:n+m+int(value)])
+				field = field[n+m+int(value):]
+			}
+		}
+	}
+	return keys, records
+}
+
+func TestProducer_Aggregation(t *testing.T) {
+	t.Run("Aggregate records by partition key", func(t *testing.T) {
+		loadDefaultVariables()
+
+		var input *kinesis.PutRecordsInput
+		mockKinesis.On("PutRecords", mock.Anything).Run(func(args mock.Arguments) {
+			input = args.Get(0).(*kinesis.PutRecordsInput)
+		}).Return(&kinesis.PutRecordsOutput{
+			Records: []*kinesis.PutRecordsResultEntry{{SequenceNumber: aws.String("1")}, {SequenceNumber: aws.String("2")}},
+		}, nil).Once()
+
+		producer, err := ctx.NewStreamProducer("events", WithAggregation(), WithFlushInterval(time.Hour))
+		assert.NoError(t, err)
+
+		assert.NoError(t, producer.PutWithKey(context.Background(), "a", []byte("first")))
+		assert.NoError(t, producer.PutWithKey(context.Background(), "b", []byte("single")))
+		assert.NoError(t, producer.PutWithKey(context.Background(), "a", []byte("second")))
+		assert.NoError(t, producer.Close(context.Background()))
+
+		assert.Len(t, input.Records, 2)
+		assert.Equal(t, "a", aws.StringValue(input.Records[0].PartitionKey))
+		keys, records := decodeAggregated(t, input.Records[0].Data)
+		assert.Equal(t, []string{"a"}, keys)
+		assert.Equal(t, [][]byte{[]byte("first"), []byte("second")}, records)
+
+		assert.Equal(t, "b", aws.StringValue(input.Records[1].PartitionKey))
+		assert.Equal(t, "single", string(input.Records[1].Data))
+	})
+
+	t.Run("Split aggregates at the record size limit", func(t *testing.T) {
+		loadDefaultVariables()
+
+		var input *kinesis.PutRecordsInput
+		mockKinesis.On("PutRecords", mock.Anything).Run(func(args mock.Arguments) {
+			input = args.Get(0).(*kinesis.PutRecordsInput)
+		}).Return(&kinesis.PutRecordsOutput{
+			Records: []*kinesis.PutRecordsResultEntry{{}, {}},
+		}, nil).Once()
+
+		producer, _ := ctx.NewStreamProducer("events", WithAggregation(), WithFlushInterval(time.Hour))
+		payload := bytes.Repeat([]byte("x"), 400*1024)
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, producer.PutWithKey(context.Background(), "k", payload))
+		}
+		assert.NoError(t, producer.Close(context.Background()))
+
+		assert.Len(t, input.Records, 2)
+		_, records := decodeAggregated(t, input.Records[0].Data)
+		assert.Len(t, records, 2)
+		assert.LessOrEqual(t, len(input.Records[0].Data), 1024*1024)
+		assert.Equal(t, payload, input.Records[1].Data)
+	})
+
+	t.Run("Count the partition key at the record size limit", func(t *testing.T) {
+		for _, extra := range []int{0, 1} {
+			loadDefaultVariables()
+
+			var input *kinesis.PutRecordsInput
+			mockKinesis.On("PutRecords", mock.Anything).Run(func(args mock.Arguments) {
+				input = args.Get(0).(*kinesis.PutRecordsInput)
+			}).Return(&kinesis.PutRecordsOutput{
+				Records: []*kinesis.PutRecordsResultEntry{{}, {}},
+			}, nil).Once()
+
+			producer, _ := ctx.NewStreamProducer("events", WithAggregation(), WithFlushInterval(time.Hour))
+			// com a chave "key", o segundo registro completa exatamente 1 MiB no registro agregado
+			assert.NoError(t, producer.PutWithKey(context.Background(), "key", bytes.Repeat([]byte("a"), 400*1024)))
+			assert.NoError(t, producer.PutWithKey(context.Background(), "key", bytes.Repeat([]byte("b"), 638928+extra)))
+			assert.NoError(t, producer.Close(context.Background()))
+
+			if extra == 0 {
+				assert.Len(t, input.Records, 1)
+				assert.Equal(t, kinesisMaxRecordBytes, len(input.Records[0].Data)+len("key"))
+			} else {
+				assert.Len(t, input.Records, 2)
+			}
+		}
+	})
+
+	t.Run("Report each record of a failed aggregate", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockKinesis.On("PutRecords", mock.Anything).Return((*kinesis.PutRecordsOutput)(nil), errors.New("unavailable"))
+
+		var mutex sync.Mutex
+		var reported []FailedRecord
+		producer, _ := ctx.NewStreamProducer("events", WithAggregation(), WithMaxAttempts(1), WithFlushInterval(time.Hour),
+			WithRecordErrorHandler(func(record FailedRecord) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				reported = append(reported, record)
+			}))
+
+		assert.NoError(t, producer.PutWithKey(context.Background(), "a", []byte("first")))
+		assert.NoError(t, producer.PutWithKey(context.Background(), "a", []byte("second")))
+		assert.Error(t, producer.Close(context.Background()))
+
+		assert.Len(t, reported, 2)
+		assert.Equal(t, "first", string(reported[0].Data))
+		assert.Equal(t, "second", string(reported[1].Data))
+	})
+
+	t.Run("Reject aggregation for Firehose", func(t *testing.T) {
+		loadDefaultVariables()
+
+		_, err := ctx.NewFirehoseProducer("delivery", WithAggregation())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestProducer_Firehose(t *testing.T) {
+	t.Run("Report records that were not delivered", func(t *testing.T) {
+		loadDefaultVariables()
+
+		var input *firehose.PutRecordBatchInput
+		mockFirehose.On("PutRecordBatch", mock.Anything).Run(func(args mock.Arguments) {
+			input = args.Get(0).(*firehose.PutRecordBatchInput)
+		}).Return((*firehose.PutRecordBatchOutput)(nil), errors.New("unavailable"))
+
+		var mutex sync.Mutex
+		var reported []FailedRecord
+		producer, err := ctx.NewFirehoseProducer("delivery", WithDelimiter([]byte("\n")), WithMaxAttempts(2),
+			WithFlushInterval(time.Hour), WithRecordErrorHandler(func(record FailedRecord) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				reported = append(reported, record)
+			}))
+		assert.NoError(t, err)
+
+		assert.NoError(t, producer.Put(context.Background(), []byte(`{"n":1}`)))
+		err = producer.Close(context.Background())
+
+		var deliveryErr *DeliveryError
+		assert.True(t, errors.As(err, &deliveryErr))
+		assert.Len(t, deliveryErr.Records, 1)
+		assert.Len(t, reported, 1)
+		assert.Equal(t, "{\"n\":1}\n", string(input.Records[0].Data))
+		mockFirehose.AssertNumberOfCalls(t, "PutRecordBatch", 2)
+	})
+}
+
+func TestPartitionKeyStrategies(t *testing.T) {
+	data := []byte(`{"tenant":42}`)
+
+	assert.Equal(t, "fixed", FixedPartitionKey("fixed")(data))
+	assert.Equal(t, HashPartitionKey()(data), HashPartitionKey()(data))
+	assert.Equal(t, "42", JSONFieldPartitionKey("tenant")(data))
+	assert.NotEmpty(t, JSONFieldPartitionKey("missing")(data))
+	assert.NotEqual(t, RandomPartitionKey()(data), RandomPartitionKey()(data))
+}
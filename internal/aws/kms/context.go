@@ -0,0 +1,329 @@
+package kms
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	kmsKey "github.com/aws/aws-sdk-go/service/kms"
+)
+
+const (
+	// maxRawMessageSize é o maior conteúdo que o KMS assina sem calcular o hash no cliente
+	maxRawMessageSize = 4096
+
+	defaultCacheTTL     = 5 * time.Minute
+	defaultCacheMaxUses = 100
+	// maxCacheEntries limita cada cache; ao atingir o limite a chave usada há mais tempo é descartada
+	maxCacheEntries = 1000
+)
+
+type KMSResource interface {
+	Encrypt(input *kmsKey.EncryptInput) (*kmsKey.EncryptOutput, error)
+	Decrypt(input *kmsKey.DecryptInput) (*kmsKey.DecryptOutput, error)
+	GenerateDataKey(input *kmsKey.GenerateDataKeyInput) (*kmsKey.GenerateDataKeyOutput, error)
+	Sign(input *kmsKey.SignInput) (*kmsKey.SignOutput, error)
+	Verify(input *kmsKey.VerifyInput) (*kmsKey.VerifyOutput, error)
+}
+
+// DataKey contém uma chave de dados em texto claro e a mesma chave cifrada pela chave KMS
+type DataKey struct {
+	KeyID          string
+	Plaintext      []byte
+	CiphertextBlob []byte
+}
+
+// cachedKey guarda uma chave de dados e controla sua validade e quantidade de usos
+type cachedKey struct {
+	dataKey   DataKey
+	expiresAt time.Time
+	lastUsed  time.Time
+	uses      int
+}
+
+// KMSCloudContext implementa CloudContext para KMS
+type KMSCloudContext struct {
+	svc KMSResource
+
+	mutex         sync.Mutex
+	cacheTTL      time.Duration
+	cacheMaxUses  int
+	dataKeys      map[string]*cachedKey
+	decryptedKeys map[string]*cachedKey
+}
+
+func NewKMSContext(sess *session.Session) *KMSCloudContext {
+	return &KMSCloudContext{
+		svc:          kmsKey.New(sess),
+		cacheTTL:     defaultCacheTTL,
+		cacheMaxUses: defaultCacheMaxUses,
+	}
+}
+
+// ConfigureCache define por quanto tempo e por quantos usos uma chave de dados é reaproveitada;
+// ttl igual a zero desabilita o cache
+func (ctx *KMSCloudContext) ConfigureCache(ttl time.Duration, maxUses int) {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	ctx.cacheTTL = ttl
+	ctx.cacheMaxUses = maxUses
+	ctx.dataKeys = nil
+	ctx.decryptedKeys = nil
+}
+
+// Encrypt cifra até 4 KB de dados diretamente com a chave KMS
+func (ctx *KMSCloudContext) Encrypt(keyID string, plaintext []byte, encryptionContext map[string]string) ([]byte, error) {
+	input := &kmsKey.EncryptInput{
+		KeyId:     aws.String(keyID),
+		Plaintext: plaintext,
+	}
+	if len(encryptionContext) > 0 {
+		input.EncryptionContext = aws.StringMap(encryptionContext)
+	}
+
+	result, err := ctx.svc.Encrypt(input)
+	if err != nil {
+		return nil, fmt.Errorf("error when encrypting with KMS: %w", err)
+	}
+	return result.CiphertextBlob, nil
+}
+
+// Decrypt decifra dados cifrados pelo KMS; o conteúdo decifrado não fica em cache
+func (ctx *KMSCloudContext) Decrypt(ciphertext []byte, encryptionContext map[string]string) ([]byte, error) {
+	result, err := ctx.decrypt(ciphertext, encryptionContext)
+	if err != nil {
+		return nil, err
+	}
+	return result.Plaintext, nil
+}
+
+// DecryptDataKey decifra uma chave de dados gerada por GenerateDataKey; a chave decifrada fica em cache
+// conforme ConfigureCache
+func (ctx *KMSCloudContext) DecryptDataKey(ciphertext []byte, encryptionContext map[string]string) (*DataKey, error) {
+	cacheKey := cacheID(string(ciphertext), encryptionContext)
+	if cached := ctx.fromCache(&ctx.decryptedKeys, cacheKey); cached != nil {
+		return cached, nil
+	}
+
+	result, err := ctx.decrypt(ciphertext, encryptionContext)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey := DataKey{
+		KeyID:          aws.StringValue(result.KeyId),
+		Plaintext:      result.Plaintext,
+		CiphertextBlob: ciphertext,
+	}
+	ctx.toCache(&ctx.decryptedKeys, cacheKey, dataKey)
+	return &dataKey, nil
+}
+
+func (ctx *KMSCloudContext) decrypt(ciphertext []byte, encryptionContext map[string]string) (*kmsKey.DecryptOutput, error) {
+	input := &kmsKey.DecryptInput{
+		CiphertextBlob: ciphertext,
+	}
+	if len(encryptionContext) > 0 {
+		input.EncryptionContext = aws.StringMap(encryptionContext)
+	}
+
+	result, err := ctx.svc.Decrypt(input)
+	if err != nil {
+		return nil, fmt.Errorf("error when decrypting with KMS: %w", err)
+	}
+	return result, nil
+}
+
+// GenerateDataKey gera uma chave de dados AES-256 para criptografia de envelope; a mesma chave é
+// reaproveitada, para a mesma chave KMS e contexto, até expirar ou atingir o limite de usos
+func (ctx *KMSCloudContext) GenerateDataKey(keyID string, encryptionContext map[string]string) (*DataKey, error) {
+	cacheKey := cacheID(keyID, encryptionContext)
+	if cached := ctx.fromCache(&ctx.dataKeys, cacheKey); cached != nil {
+		return cached, nil
+	}
+
+	input := &kmsKey.GenerateDataKeyInput{
+		KeyId:   aws.String(keyID),
+		KeySpec: aws.String(kmsKey.DataKeySpecAes256),
+	}
+	if len(encryptionContext) > 0 {
+		input.EncryptionContext = aws.StringMap(encryptionContext)
+	}
+
+	result, err := ctx.svc.GenerateDataKey(input)
+	if err != nil {
+		return nil, fmt.Errorf("error when generating KMS data key: %w", err)
+	}
+
+	dataKey := DataKey{
+		KeyID:          aws.StringValue(result.KeyId),
+		Plaintext:      result.Plaintext,
+		CiphertextBlob: result.CiphertextBlob,
+	}
+	ctx.toCache(&ctx.dataKeys, cacheKey, dataKey)
+	return &dataKey, nil
+}
+
+// Sign assina a mensagem com uma chave assimétrica; mensagens maiores que 4 KB são assinadas pelo
+// hash definido no algoritmo (SHA-256, SHA-384 ou SHA-512)
+func (ctx *KMSCloudContext) Sign(keyID string, message []byte, algorithm string) ([]byte, error) {
+	payload, messageType, err := signingPayload(message, algorithm)
+	if err != nil {
+		return nil, err
+	}
+	result, err := ctx.svc.Sign(&kmsKey.SignInput{
+		KeyId:            aws.String(keyID),
+		Message:          payload,
+		MessageType:      aws.String(messageType),
+		SigningAlgorithm: aws.String(algorithm),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when signing with KMS: %w", err)
+	}
+	return result.Signature, nil
+}
+
+// Verify confere a assinatura da mensagem; uma assinatura que não confere retorna false sem erro
+func (ctx *KMSCloudContext) Verify(keyID string, message, signature []byte, algorithm string) (bool, error) {
+	payload, messageType, err := signingPayload(message, algorithm)
+	if err != nil {
+		return false, err
+	}
+	result, err := ctx.svc.Verify(&kmsKey.VerifyInput{
+		KeyId:            aws.String(keyID),
+		Message:          payload,
+		MessageType:      aws.String(messageType),
+		Signature:        signature,
+		SigningAlgorithm: aws.String(algorithm),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == kmsKey.ErrCodeKMSInvalidSignatureException {
+			return false, nil
+		}
+		return false, fmt.Errorf("error when verifying signature with KMS: %w", err)
+	}
+	return aws.BoolValue(result.SignatureValid), nil
+}
+
+// signingPayload envia a mensagem como RAW até 4 KB e, acima disso, o hash exigido pelo algoritmo,
+// já que o KMS recusa digests de tamanho diferente
+func signingPayload(message []byte, algorithm string) ([]byte, string, error) {
+	if len(message) <= maxRawMessageSize {
+		return message, kmsKey.MessageTypeRaw, nil
+	}
+
+	switch {
+	case algorithm == kmsKey.SigningAlgorithmSpecSm2dsa:
+		// O digest do SM2DSA usa SM3, que não está disponível na biblioteca padrão
+		return nil, "", fmt.Errorf("messages larger than 4 KB cannot be signed with %s", algorithm)
+	case strings.HasSuffix(algorithm, "SHA_384"):
+		digest := sha512.Sum384(message)
+		return digest[:], kmsKey.MessageTypeDigest, nil
+	case strings.HasSuffix(algorithm, "SHA_512"):
+		digest := sha512.Sum512(message)
+		return digest[:], kmsKey.MessageTypeDigest, nil
+	default:
+		digest := sha256.Sum256(message)
+		return digest[:], kmsKey.MessageTypeDigest, nil
+	}
+}
+
+// fromCache retorna uma cópia da chave em cache, descartando-a quando expirada ou esgotada
+func (ctx *KMSCloudContext) fromCache(cache *map[string]*cachedKey, id string) *DataKey {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	cached, ok := (*cache)[id]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(cached.expiresAt) || (ctx.cacheMaxUses > 0 && cached.uses >= ctx.cacheMaxUses) {
+		delete(*cache, id)
+		return nil
+	}
+
+	cached.uses++
+	cached.lastUsed = time.Now()
+	dataKey := copyDataKey(cached.dataKey)
+	return &dataKey
+}
+
+func (ctx *KMSCloudContext) toCache(cache *map[string]*cachedKey, id string, dataKey DataKey) {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	if ctx.cacheTTL <= 0 {
+		return
+	}
+	if *cache == nil {
+		*cache = make(map[string]*cachedKey)
+	}
+
+	now := time.Now()
+	if _, ok := (*cache)[id]; !ok && len(*cache) >= maxCacheEntries {
+		evict(*cache, now)
+	}
+	(*cache)[id] = &cachedKey{
+		dataKey:   copyDataKey(dataKey),
+		expiresAt: now.Add(ctx.cacheTTL),
+		lastUsed:  now,
+		uses:      1,
+	}
+}
+
+// evict remove as chaves expiradas e, se nenhuma tiver expirado, a usada há mais tempo
+func evict(cache map[string]*cachedKey, now time.Time) {
+	var oldestID string
+	var oldest time.Time
+	for id, cached := range cache {
+		if now.After(cached.expiresAt) {
+			delete(cache, id)
+			continue
+		}
+		if oldestID == "" || cached.lastUsed.Before(oldest) {
+			oldestID, oldest = id, cached.lastUsed
+		}
+	}
+	if len(cache) >= maxCacheEntries {
+		delete(cache, oldestID)
+	}
+}
+
+// copyDataKey evita que o chamador altere, ou zere, a chave guardada no cache
+func copyDataKey(dataKey DataKey) DataKey {
+	return DataKey{
+		KeyID:          dataKey.KeyID,
+		Plaintext:      append([]byte(nil), dataKey.Plaintext...),
+		CiphertextBlob: append([]byte(nil), dataKey.CiphertextBlob...),
+	}
+}
+
+// cacheID combina a chave com o contexto de criptografia, já que ambos precisam coincidir
+func cacheID(key string, encryptionContext map[string]string) string {
+	names := make([]string, 0, len(encryptionContext))
+	for name := range encryptionContext {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	hash.Write([]byte(key))
+	for _, name := range names {
+		hash.Write([]byte{0})
+		hash.Write([]byte(name))
+		hash.Write([]byte{0})
+		hash.Write([]byte(encryptionContext[name]))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
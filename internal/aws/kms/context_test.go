@@ -0,0 +1,251 @@
+package kms
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	kmsKey "github.com/aws/aws-sdk-go/service/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock para KMS
+type mockKMSClient struct {
+	mock.Mock
+}
+
+func (m *mockKMSClient) Encrypt(input *kmsKey.EncryptInput) (*kmsKey.EncryptOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*kmsKey.EncryptOutput), args.Error(1)
+}
+
+func (m *mockKMSClient) Decrypt(input *kmsKey.DecryptInput) (*kmsKey.DecryptOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*kmsKey.DecryptOutput), args.Error(1)
+}
+
+func (m *mockKMSClient) GenerateDataKey(input *kmsKey.GenerateDataKeyInput) (*kmsKey.GenerateDataKeyOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*kmsKey.GenerateDataKeyOutput), args.Error(1)
+}
+
+func (m *mockKMSClient) Sign(input *kmsKey.SignInput) (*kmsKey.SignOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*kmsKey.SignOutput), args.Error(1)
+}
+
+func (m *mockKMSClient) Verify(input *kmsKey.VerifyInput) (*kmsKey.VerifyOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*kmsKey.VerifyOutput), args.Error(1)
+}
+
+var (
+	mockKMS *mockKMSClient
+	ctx     *KMSCloudContext
+)
+
+func loadDefaultVariables() {
+	mockKMS = new(mockKMSClient)
+	ctx = &KMSCloudContext{
+		svc:          mockKMS,
+		cacheTTL:     defaultCacheTTL,
+		cacheMaxUses: defaultCacheMaxUses,
+	}
+}
+
+func TestKMSCloudContext_Encrypt(t *testing.T) {
+	t.Run("Encrypt with encryption context", func(t *testing.T) {
+		loadDefaultVariables()
+
+		encryptionContext := map[string]string{"tenant": "acme"}
+		mockKMS.On("Encrypt", mock.MatchedBy(func(input *kmsKey.EncryptInput) bool {
+			return aws.StringValue(input.KeyId) == "alias/app" &&
+				aws.StringValue(input.EncryptionContext["tenant"]) == "acme"
+		})).Return(&kmsKey.EncryptOutput{CiphertextBlob: []byte("cipher")}, nil)
+
+		result, err := ctx.Encrypt("alias/app", []byte("secret"), encryptionContext)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("cipher"), result)
+	})
+}
+
+func TestKMSCloudContext_Decrypt(t *testing.T) {
+	t.Run("Decrypted content is not cached", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockKMS.On("Decrypt", mock.Anything).Return(&kmsKey.DecryptOutput{
+			Plaintext: []byte("secret"),
+		}, nil)
+
+		for i := 0; i < 2; i++ {
+			result, err := ctx.Decrypt([]byte("cipher"), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("secret"), result)
+		}
+
+		mockKMS.AssertNumberOfCalls(t, "Decrypt", 2)
+		assert.Empty(t, ctx.decryptedKeys)
+	})
+}
+
+func TestKMSCloudContext_DecryptDataKey(t *testing.T) {
+	t.Run("Decrypted keys are served from cache", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockKMS.On("Decrypt", mock.Anything).Return(&kmsKey.DecryptOutput{
+			KeyId:     aws.String("arn:key"),
+			Plaintext: []byte("plain"),
+		}, nil).Once()
+
+		first, err := ctx.DecryptDataKey([]byte("cipher"), nil)
+		assert.NoError(t, err)
+		second, err := ctx.DecryptDataKey([]byte("cipher"), nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []byte("plain"), first.Plaintext)
+		assert.Equal(t, "arn:key", first.KeyID)
+		assert.Equal(t, first, second)
+		mockKMS.AssertNumberOfCalls(t, "Decrypt", 1)
+	})
+
+	t.Run("Callers cannot corrupt the cached plaintext", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockKMS.On("Decrypt", mock.Anything).Return(&kmsKey.DecryptOutput{
+			Plaintext: []byte("plain"),
+		}, nil).Once()
+
+		first, err := ctx.DecryptDataKey([]byte("cipher"), nil)
+		assert.NoError(t, err)
+		for i := range first.Plaintext {
+			first.Plaintext[i] = 0
+		}
+
+		second, err := ctx.DecryptDataKey([]byte("cipher"), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("plain"), second.Plaintext)
+	})
+
+	t.Run("Cache is bounded", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockKMS.On("Decrypt", mock.Anything).Return(&kmsKey.DecryptOutput{
+			Plaintext: []byte("plain"),
+		}, nil)
+
+		for i := 0; i < maxCacheEntries+10; i++ {
+			_, err := ctx.DecryptDataKey([]byte(fmt.Sprintf("cipher-%d", i)), nil)
+			assert.NoError(t, err)
+		}
+
+		assert.Len(t, ctx.decryptedKeys, maxCacheEntries)
+	})
+
+	t.Run("Different encryption context is not served from cache", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockKMS.On("Decrypt", mock.Anything).Return(&kmsKey.DecryptOutput{
+			Plaintext: []byte("plain"),
+		}, nil).Twice()
+
+		_, err := ctx.DecryptDataKey([]byte("cipher"), map[string]string{"tenant": "a"})
+		assert.NoError(t, err)
+		_, err = ctx.DecryptDataKey([]byte("cipher"), map[string]string{"tenant": "b"})
+		assert.NoError(t, err)
+
+		mockKMS.AssertNumberOfCalls(t, "Decrypt", 2)
+	})
+}
+
+func TestKMSCloudContext_GenerateDataKey(t *testing.T) {
+	t.Run("Reuse data key until max uses", func(t *testing.T) {
+		loadDefaultVariables()
+		ctx.ConfigureCache(time.Minute, 2)
+
+		mockKMS.On("GenerateDataKey", mock.MatchedBy(func(input *kmsKey.GenerateDataKeyInput) bool {
+			return aws.StringValue(input.KeySpec) == kmsKey.DataKeySpecAes256
+		})).Return(&kmsKey.GenerateDataKeyOutput{
+			KeyId:          aws.String("arn:key"),
+			Plaintext:      bytes.Repeat([]byte{1}, 32),
+			CiphertextBlob: []byte("wrapped"),
+		}, nil)
+
+		for i := 0; i < 3; i++ {
+			dataKey, err := ctx.GenerateDataKey("alias/app", nil)
+			assert.NoError(t, err)
+			assert.Equal(t, "arn:key", dataKey.KeyID)
+			assert.Len(t, dataKey.Plaintext, 32)
+		}
+
+		mockKMS.AssertNumberOfCalls(t, "GenerateDataKey", 2)
+	})
+
+	t.Run("Cache disabled", func(t *testing.T) {
+		loadDefaultVariables()
+		ctx.ConfigureCache(0, 0)
+
+		mockKMS.On("GenerateDataKey", mock.Anything).Return(&kmsKey.GenerateDataKeyOutput{
+			Plaintext:      bytes.Repeat([]byte{1}, 32),
+			CiphertextBlob: []byte("wrapped"),
+		}, nil)
+
+		_, err := ctx.GenerateDataKey("alias/app", nil)
+		assert.NoError(t, err)
+		_, err = ctx.GenerateDataKey("alias/app", nil)
+		assert.NoError(t, err)
+
+		mockKMS.AssertNumberOfCalls(t, "GenerateDataKey", 2)
+	})
+}
+
+func TestKMSCloudContext_SignVerify(t *testing.T) {
+	t.Run("Large messages are signed by digest", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockKMS.On("Sign", mock.MatchedBy(func(input *kmsKey.SignInput) bool {
+			return aws.StringValue(input.MessageType) == kmsKey.MessageTypeDigest && len(input.Message) == 32
+		})).Return(&kmsKey.SignOutput{Signature: []byte("sig")}, nil)
+
+		signature, err := ctx.Sign("alias/sign", bytes.Repeat([]byte("a"), 5000), kmsKey.SigningAlgorithmSpecEcdsaSha256)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("sig"), signature)
+	})
+
+	t.Run("Large messages use the digest of the algorithm", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockKMS.On("Sign", mock.MatchedBy(func(input *kmsKey.SignInput) bool {
+			return len(input.Message) == 48
+		})).Return(&kmsKey.SignOutput{Signature: []byte("sig384")}, nil)
+		mockKMS.On("Verify", mock.MatchedBy(func(input *kmsKey.VerifyInput) bool {
+			return len(input.Message) == 64
+		})).Return(&kmsKey.VerifyOutput{SignatureValid: aws.Bool(true)}, nil)
+
+		message := bytes.Repeat([]byte("a"), 5000)
+		signature, err := ctx.Sign("alias/sign", message, kmsKey.SigningAlgorithmSpecEcdsaSha384)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("sig384"), signature)
+
+		valid, err := ctx.Verify("alias/sign", message, []byte("sig"), kmsKey.SigningAlgorithmSpecRsassaPssSha512)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Invalid signature returns false", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockKMS.On("Verify", mock.Anything).Return((*kmsKey.VerifyOutput)(nil),
+			awserr.New(kmsKey.ErrCodeKMSInvalidSignatureException, "invalid", nil))
+
+		valid, err := ctx.Verify("alias/sign", []byte("msg"), []byte("sig"), kmsKey.SigningAlgorithmSpecEcdsaSha256)
+
+		assert.NoError(t, err)
+		assert.False(t, valid)
+	})
+}
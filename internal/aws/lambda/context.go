@@ -0,0 +1,182 @@
+package lambda
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	lambdaFunction "github.com/aws/aws-sdk-go/service/lambda"
+)
+
+type LambdaResource interface {
+	Invoke(input *lambdaFunction.InvokeInput) (*lambdaFunction.InvokeOutput, error)
+}
+
+// LambdaCloudContext implementa CloudContext para Lambda
+type LambdaCloudContext struct {
+	svc LambdaResource
+}
+
+func NewLambdaContext(sess *session.Session) *LambdaCloudContext {
+	return &LambdaCloudContext{
+		svc: lambdaFunction.New(sess),
+	}
+}
+
+// InvokeOption configura uma invocação individual
+type InvokeOption func(*InvokeOptions)
+
+// InvokeOptions reúne as configurações aplicadas em uma invocação
+type InvokeOptions struct {
+	// Qualifier seleciona a versão ou o alias da função
+	Qualifier string
+
+	// Async invoca a função com o tipo Event, sem aguardar a resposta
+	Async bool
+
+	// LogTail solicita os últimos 4 KB do log de execução; disponível apenas em invocações síncronas
+	LogTail bool
+}
+
+// WithQualifier invoca a versão ou o alias informado
+func WithQualifier(qualifier string) InvokeOption {
+	return func(o *InvokeOptions) {
+		o.Qualifier = qualifier
+	}
+}
+
+// WithAsync invoca a função com o tipo Event, sem aguardar a resposta
+func WithAsync() InvokeOption {
+	return func(o *InvokeOptions) {
+		o.Async = true
+	}
+}
+
+// WithLogTail solicita os últimos 4 KB do log de execução
+func WithLogTail() InvokeOption {
+	return func(o *InvokeOptions) {
+		o.LogTail = true
+	}
+}
+
+func newInvokeOptions(opts []InvokeOption) *InvokeOptions {
+	options := &InvokeOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
+
+// InvokeResult contém a resposta bruta de uma invocação
+type InvokeResult struct {
+	StatusCode      int64
+	Payload         []byte
+	ExecutedVersion string
+
+	// LogTail contém o final do log de execução já decodificado, quando solicitado com WithLogTail
+	LogTail string
+}
+
+// FunctionError indica que a função foi executada, mas retornou erro; Type é "Handled" ou "Unhandled"
+type FunctionError struct {
+	Type         string   `json:"-"`
+	ErrorMessage string   `json:"errorMessage"`
+	ErrorType    string   `json:"errorType"`
+	StackTrace   []string `json:"stackTrace"`
+	LogTail      string   `json:"-"`
+}
+
+func (e *FunctionError) Error() string {
+	if e.ErrorType != "" {
+		return fmt.Sprintf("lambda function error (%s): %s: %s", e.Type, e.ErrorType, e.ErrorMessage)
+	}
+	return fmt.Sprintf("lambda function error (%s): %s", e.Type, e.ErrorMessage)
+}
+
+// InvokeFunc é a assinatura de InvokeRaw, usada por Invoke para converter requisição e resposta
+type InvokeFunc func(functionName string, payload []byte, opts ...InvokeOption) (*InvokeResult, error)
+
+// InvokeRaw invoca a função com o payload informado; quando a função retorna erro, o resultado é
+// acompanhado de um *FunctionError com a mensagem, o tipo e o stack trace reportados pela função
+func (ctx *LambdaCloudContext) InvokeRaw(functionName string, payload []byte, opts ...InvokeOption) (*InvokeResult, error) {
+	options := newInvokeOptions(opts)
+
+	input := &lambdaFunction.InvokeInput{
+		FunctionName:   aws.String(functionName),
+		Payload:        payload,
+		InvocationType: aws.String(lambdaFunction.InvocationTypeRequestResponse),
+	}
+	if options.Async {
+		input.InvocationType = aws.String(lambdaFunction.InvocationTypeEvent)
+	}
+	if options.Qualifier != "" {
+		input.Qualifier = aws.String(options.Qualifier)
+	}
+	if options.LogTail && !options.Async {
+		input.LogType = aws.String(lambdaFunction.LogTypeTail)
+	}
+
+	output, err := ctx.svc.Invoke(input)
+	if err != nil {
+		return nil, fmt.Errorf("error when invoking lambda function: %w", err)
+	}
+
+	result := &InvokeResult{
+		StatusCode:      aws.Int64Value(output.StatusCode),
+		Payload:         output.Payload,
+		ExecutedVersion: aws.StringValue(output.ExecutedVersion),
+	}
+	if logResult := aws.StringValue(output.LogResult); logResult != "" {
+		logTail, err := base64.StdEncoding.DecodeString(logResult)
+		if err != nil {
+			return nil, fmt.Errorf("error when decoding lambda log tail: %w", err)
+		}
+		result.LogTail = string(logTail)
+	}
+
+	if functionError := aws.StringValue(output.FunctionError); functionError != "" {
+		fnErr := &FunctionError{Type: functionError, LogTail: result.LogTail}
+		if err := json.Unmarshal(output.Payload, fnErr); err != nil || fnErr.ErrorMessage == "" {
+			fnErr.ErrorMessage = string(output.Payload)
+		}
+		return result, fnErr
+	}
+	return result, nil
+}
+
+// Invoke converte a requisição para JSON, invoca a função e converte a resposta para Resp; requisições
+// []byte ou json.RawMessage são enviadas sem conversão. Em invocações assíncronas a resposta é o valor
+// zero de Resp
+func Invoke[Req, Resp any](invoke InvokeFunc, functionName string, request Req, opts ...InvokeOption) (Resp, *InvokeResult, error) {
+	var response Resp
+
+	var payload []byte
+	switch value := any(request).(type) {
+	case []byte:
+		payload = value
+	case json.RawMessage:
+		payload = value
+	default:
+		encoded, err := json.Marshal(request)
+		if err != nil {
+			return response, nil, fmt.Errorf("error when encoding lambda request: %w", err)
+		}
+		payload = encoded
+	}
+
+	result, err := invoke(functionName, payload, opts...)
+	if err != nil {
+		return response, result, err
+	}
+
+	if len(result.Payload) > 0 {
+		if err := json.Unmarshal(result.Payload, &response); err != nil {
+			return response, result, fmt.Errorf("error when decoding lambda response: %w", err)
+		}
+	}
+	return response, result, nil
+}
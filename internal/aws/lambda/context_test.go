@@ -0,0 +1,102 @@
+package lambda
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock para Lambda
+type mockLambdaClient struct {
+	mock.Mock
+}
+
+func (m *mockLambdaClient) Invoke(input *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*lambda.InvokeOutput), args.Error(1)
+}
+
+type priceRequest struct {
+	SKU string `json:"sku"`
+}
+
+type priceResponse struct {
+	Price float64 `json:"price"`
+}
+
+var (
+	mockLambda *mockLambdaClient
+	ctx        *LambdaCloudContext
+)
+
+func loadDefaultVariables() {
+	mockLambda = new(mockLambdaClient)
+	ctx = &LambdaCloudContext{
+		svc: mockLambda,
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	t.Run("Typed request and response with alias and log tail", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockLambda.On("Invoke", mock.MatchedBy(func(input *lambda.InvokeInput) bool {
+			return aws.StringValue(input.InvocationType) == lambda.InvocationTypeRequestResponse &&
+				aws.StringValue(input.Qualifier) == "live" &&
+				aws.StringValue(input.LogType) == lambda.LogTypeTail &&
+				string(input.Payload) == `{"sku":"A1"}`
+		})).Return(&lambda.InvokeOutput{
+			StatusCode:      aws.Int64(200),
+			Payload:         []byte(`{"price":9.9}`),
+			ExecutedVersion: aws.String("7"),
+			LogResult:       aws.String(base64.StdEncoding.EncodeToString([]byte("REPORT Duration: 3 ms"))),
+		}, nil)
+
+		response, result, err := Invoke[priceRequest, priceResponse](ctx.InvokeRaw, "pricing", priceRequest{SKU: "A1"},
+			WithQualifier("live"), WithLogTail())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 9.9, response.Price)
+		assert.Equal(t, "7", result.ExecutedVersion)
+		assert.Equal(t, "REPORT Duration: 3 ms", result.LogTail)
+	})
+
+	t.Run("Asynchronous invocation", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockLambda.On("Invoke", mock.MatchedBy(func(input *lambda.InvokeInput) bool {
+			return aws.StringValue(input.InvocationType) == lambda.InvocationTypeEvent && input.LogType == nil
+		})).Return(&lambda.InvokeOutput{StatusCode: aws.Int64(202)}, nil)
+
+		response, result, err := Invoke[priceRequest, priceResponse](ctx.InvokeRaw, "pricing", priceRequest{SKU: "A1"},
+			WithAsync(), WithLogTail())
+
+		assert.NoError(t, err)
+		assert.Equal(t, priceResponse{}, response)
+		assert.Equal(t, int64(202), result.StatusCode)
+	})
+
+	t.Run("Function error", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{
+			StatusCode:    aws.Int64(200),
+			FunctionError: aws.String("Unhandled"),
+			Payload:       []byte(`{"errorMessage":"sku not found","errorType":"NotFound","stackTrace":["main.go:10"]}`),
+		}, nil)
+
+		_, _, err := Invoke[[]byte, priceResponse](ctx.InvokeRaw, "pricing", []byte(`{"sku":"X"}`))
+
+		var fnErr *FunctionError
+		assert.True(t, errors.As(err, &fnErr))
+		assert.Equal(t, "Unhandled", fnErr.Type)
+		assert.Equal(t, "NotFound", fnErr.ErrorType)
+		assert.Equal(t, "sku not found", fnErr.ErrorMessage)
+		assert.Equal(t, []string{"main.go:10"}, fnErr.StackTrace)
+	})
+}
@@ -0,0 +1,49 @@
+package rds
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// Connector implementa driver.Connector gerando o DSN a cada nova conexão; quando a conexão falha
+// por autenticação, como após a rotação da senha, o segredo é lido novamente e a conexão repetida uma vez
+type Connector struct {
+	provider *Provider
+	driver   driver.Driver
+}
+
+// NewConnector cria um driver.Connector para uso com sql.OpenDB
+func NewConnector(provider *Provider, drv driver.Driver) *Connector {
+	return &Connector{provider: provider, driver: drv}
+}
+
+// Connect abre uma nova conexão com o DSN atual
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connect(ctx)
+	if err != nil && c.provider.options.IsAuthError(err) {
+		c.provider.Refresh()
+		conn, err = c.connect(ctx)
+	}
+	return conn, err
+}
+
+// Driver retorna o driver usado nas conexões
+func (c *Connector) Driver() driver.Driver {
+	return c.driver
+}
+
+func (c *Connector) connect(ctx context.Context) (driver.Conn, error) {
+	dsn, err := c.provider.DSN()
+	if err != nil {
+		return nil, err
+	}
+
+	if driverCtx, ok := c.driver.(driver.DriverContext); ok {
+		connector, err := driverCtx.OpenConnector(dsn)
+		if err != nil {
+			return nil, err
+		}
+		return connector.Connect(ctx)
+	}
+	return c.driver.Open(dsn)
+}
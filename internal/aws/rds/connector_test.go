@@ -0,0 +1,67 @@
+package rds
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDriver aceita apenas DSNs com a senha esperada
+type fakeDriver struct {
+	password string
+	opened   []string
+}
+
+func (d *fakeDriver) Open(dsn string) (driver.Conn, error) {
+	d.opened = append(d.opened, dsn)
+
+	parsed, _ := url.Parse(dsn)
+	if password, _ := parsed.User.Password(); password != d.password {
+		return nil, errors.New(`pq: password authentication failed for user "app"`)
+	}
+	return fakeConn{}, nil
+}
+
+type fakeConn struct {
+	driver.Conn
+}
+
+func TestConnector_Connect(t *testing.T) {
+	t.Run("Re-read the secret after rotation", func(t *testing.T) {
+		secrets := []string{
+			`{"engine":"postgres","host":"db","username":"app","password":"old","dbname":"orders"}`,
+			`{"engine":"postgres","host":"db","username":"app","password":"new","dbname":"orders"}`,
+		}
+		loads := 0
+		provider := NewProvider(func() ([]byte, error) {
+			content := secrets[min(loads, len(secrets)-1)]
+			loads++
+			return []byte(content), nil
+		}, nil, "")
+
+		drv := &fakeDriver{password: "new"}
+		connector := NewConnector(provider, drv)
+
+		conn, err := connector.Connect(context.Background())
+
+		assert.NoError(t, err)
+		assert.NotNil(t, conn)
+		assert.Equal(t, 2, loads)
+		assert.Len(t, drv.opened, 2)
+		assert.Equal(t, drv, connector.Driver())
+	})
+
+	t.Run("Other errors are not retried", func(t *testing.T) {
+		provider := NewProvider(staticLoader(postgresSecret), nil, "", WithAuthErrorCheck(func(error) bool { return false }))
+		drv := &fakeDriver{password: "other"}
+
+		_, err := NewConnector(provider, drv).Connect(context.Background())
+
+		assert.Error(t, err)
+		assert.Len(t, drv.opened, 1)
+	})
+}
@@ -0,0 +1,289 @@
+package rds
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/rds/rdsutils"
+)
+
+// Engine identifica o formato de DSN gerado
+type Engine string
+
+const (
+	EnginePostgres Engine = "postgres"
+	EngineMySQL    Engine = "mysql"
+)
+
+// Secret é o segredo no formato padrão do RDS no Secrets Manager
+type Secret struct {
+	Engine               string      `json:"engine"`
+	Host                 string      `json:"host"`
+	Port                 json.Number `json:"port"`
+	Username             string      `json:"username"`
+	Password             string      `json:"password"`
+	DBName               string      `json:"dbname"`
+	DBInstanceIdentifier string      `json:"dbInstanceIdentifier"`
+	DBClusterIdentifier  string      `json:"dbClusterIdentifier"`
+}
+
+// ParseSecret interpreta o JSON do segredo do RDS
+func ParseSecret(content []byte) (*Secret, error) {
+	var secret Secret
+	if err := json.Unmarshal(content, &secret); err != nil {
+		return nil, fmt.Errorf("error when analyzing RDS secret JSON: %w", err)
+	}
+	if secret.Host == "" || secret.Username == "" {
+		return nil, errors.New("the RDS secret must contain host and username")
+	}
+	return &secret, nil
+}
+
+// SecretLoader obtém o conteúdo atual do segredo do RDS
+type SecretLoader func() ([]byte, error)
+
+// Option configura a geração do DSN
+type Option func(*Options)
+
+// Options reúne as configurações da geração do DSN
+type Options struct {
+	// Engine substitui o engine informado no segredo
+	Engine Engine
+
+	// Database substitui o dbname informado no segredo
+	Database string
+
+	// Params são acrescentados ao DSN, como sslmode no Postgres ou parseTime no MySQL
+	Params map[string]string
+
+	// IAMAuth usa um token de autenticação IAM, gerado a cada conexão, no lugar da senha do segredo
+	IAMAuth bool
+
+	// IsAuthError identifica as falhas de autenticação que provocam a releitura do segredo
+	IsAuthError func(err error) bool
+}
+
+// WithEngine força o formato de DSN, ignorando o engine do segredo
+func WithEngine(engine Engine) Option {
+	return func(o *Options) {
+		o.Engine = engine
+	}
+}
+
+// WithDatabase conecta ao banco informado no lugar do dbname do segredo
+func WithDatabase(database string) Option {
+	return func(o *Options) {
+		o.Database = database
+	}
+}
+
+// WithParams acrescenta parâmetros ao DSN
+func WithParams(params map[string]string) Option {
+	return func(o *Options) {
+		o.Params = params
+	}
+}
+
+// WithIAMAuth autentica com tokens IAM do RDS no lugar da senha do segredo
+func WithIAMAuth() Option {
+	return func(o *Options) {
+		o.IAMAuth = true
+	}
+}
+
+// WithAuthErrorCheck substitui a identificação padrão das falhas de autenticação
+func WithAuthErrorCheck(isAuthError func(err error) bool) Option {
+	return func(o *Options) {
+		o.IsAuthError = isAuthError
+	}
+}
+
+func newOptions(opts []Option) *Options {
+	options := &Options{IsAuthError: IsAuthError}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
+
+// Provider gera DSNs a partir do segredo do RDS, mantendo o segredo em cache até Refresh
+type Provider struct {
+	load    SecretLoader
+	creds   *credentials.Credentials
+	region  string
+	options *Options
+
+	mutex  sync.Mutex
+	secret *Secret
+}
+
+// NewProvider cria um Provider que lê o segredo com load; creds e region são usados apenas com WithIAMAuth
+func NewProvider(load SecretLoader, creds *credentials.Credentials, region string, opts ...Option) *Provider {
+	return &Provider{
+		load:    load,
+		creds:   creds,
+		region:  region,
+		options: newOptions(opts),
+	}
+}
+
+// DSN gera o DSN com o segredo em cache, lendo-o na primeira chamada
+func (p *Provider) DSN() (string, error) {
+	secret, err := p.currentSecret()
+	if err != nil {
+		return "", err
+	}
+	return p.buildDSN(secret)
+}
+
+// Refresh descarta o segredo em cache, forçando uma nova leitura na próxima geração de DSN
+func (p *Provider) Refresh() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.secret = nil
+}
+
+func (p *Provider) currentSecret() (*Secret, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.secret != nil {
+		return p.secret, nil
+	}
+
+	content, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := ParseSecret(content)
+	if err != nil {
+		return nil, err
+	}
+	p.secret = secret
+	return secret, nil
+}
+
+func (p *Provider) buildDSN(secret *Secret) (string, error) {
+	engine := p.options.Engine
+	if engine == "" {
+		engine = engineOf(secret.Engine)
+	}
+
+	port := secret.Port.String()
+	if port == "" {
+		port = defaultPort(engine)
+	}
+	address := net.JoinHostPort(secret.Host, port)
+
+	database := secret.DBName
+	if p.options.Database != "" {
+		database = p.options.Database
+	}
+
+	params := make(map[string]string, len(p.options.Params)+1)
+	for name, value := range p.options.Params {
+		params[name] = value
+	}
+
+	password := secret.Password
+	if p.options.IAMAuth {
+		token, err := rdsutils.BuildAuthToken(address, p.region, secret.Username, p.creds)
+		if err != nil {
+			return "", fmt.Errorf("error when building RDS IAM auth token: %w", err)
+		}
+		password = token
+
+		// tokens IAM exigem TLS no Postgres e o envio da senha em texto no MySQL
+		if engine == EnginePostgres {
+			setDefault(params, "sslmode", "require")
+		} else {
+			setDefault(params, "tls", "true")
+			setDefault(params, "allowCleartextPasswords", "true")
+		}
+	}
+
+	switch engine {
+	case EnginePostgres:
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(secret.Username, password),
+			Host:     address,
+			Path:     "/" + database,
+			RawQuery: encodeParams(params),
+		}
+		return dsn.String(), nil
+
+	case EngineMySQL:
+		dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s", secret.Username, password, address, database)
+		if query := encodeParams(params); query != "" {
+			dsn += "?" + query
+		}
+		return dsn, nil
+
+	default:
+		return "", fmt.Errorf("unsupported RDS engine: %s", engine)
+	}
+}
+
+// IsAuthError identifica as falhas de autenticação do Postgres (SQLSTATE 28P01 e 28000) e do MySQL (erro 1045)
+func IsAuthError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	message := err.Error()
+	for _, marker := range []string{"28P01", "28000", "password authentication failed", "Error 1045", "Access denied for user"} {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func engineOf(engine string) Engine {
+	switch {
+	case strings.Contains(engine, "postgres"):
+		return EnginePostgres
+	case strings.Contains(engine, "mysql"), strings.Contains(engine, "mariadb"), engine == "aurora":
+		return EngineMySQL
+	default:
+		return Engine(engine)
+	}
+}
+
+func defaultPort(engine Engine) string {
+	if engine == EnginePostgres {
+		return "5432"
+	}
+	return "3306"
+}
+
+func setDefault(params map[string]string, name, value string) {
+	if _, ok := params[name]; !ok {
+		params[name] = value
+	}
+}
+
+// encodeParams gera a query em ordem alfabética, mantendo o DSN estável entre chamadas
+func encodeParams(params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, url.QueryEscape(name)+"="+url.QueryEscape(params[name]))
+	}
+	return strings.Join(pairs, "&")
+}
@@ -0,0 +1,91 @@
+package rds
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+const postgresSecret = `{"engine":"postgres","host":"db.example.com","port":5432,"username":"app","password":"p@ss/w:rd","dbname":"orders"}`
+
+func staticLoader(content string) SecretLoader {
+	return func() ([]byte, error) {
+		return []byte(content), nil
+	}
+}
+
+func TestProvider_DSN(t *testing.T) {
+	t.Run("Postgres DSN escapes the password", func(t *testing.T) {
+		provider := NewProvider(staticLoader(postgresSecret), nil, "", WithParams(map[string]string{"sslmode": "verify-full"}))
+
+		dsn, err := provider.DSN()
+
+		assert.NoError(t, err)
+		parsed, err := url.Parse(dsn)
+		assert.NoError(t, err)
+		password, _ := parsed.User.Password()
+		assert.Equal(t, "p@ss/w:rd", password)
+		assert.Equal(t, "db.example.com:5432", parsed.Host)
+		assert.Equal(t, "/orders", parsed.Path)
+		assert.Equal(t, "verify-full", parsed.Query().Get("sslmode"))
+	})
+
+	t.Run("MySQL DSN with string port and database override", func(t *testing.T) {
+		secret := `{"engine":"aurora-mysql","host":"db","port":"3307","username":"app","password":"secret","dbname":"a"}`
+		provider := NewProvider(staticLoader(secret), nil, "", WithDatabase("b"), WithParams(map[string]string{"parseTime": "true"}))
+
+		dsn, err := provider.DSN()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "app:secret@tcp(db:3307)/b?parseTime=true", dsn)
+	})
+
+	t.Run("IAM auth token replaces the password", func(t *testing.T) {
+		creds := credentials.NewStaticCredentials("AKID", "SECRET", "")
+		provider := NewProvider(staticLoader(postgresSecret), creds, "us-east-1", WithIAMAuth())
+
+		dsn, err := provider.DSN()
+
+		assert.NoError(t, err)
+		parsed, _ := url.Parse(dsn)
+		password, _ := parsed.User.Password()
+		assert.True(t, strings.HasPrefix(password, "db.example.com:5432?Action=connect&DBUser=app"))
+		assert.Contains(t, password, "X-Amz-Signature=")
+		assert.Equal(t, "require", parsed.Query().Get("sslmode"))
+	})
+
+	t.Run("Invalid secret", func(t *testing.T) {
+		provider := NewProvider(staticLoader(`{"engine":"postgres"}`), nil, "")
+
+		_, err := provider.DSN()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Secret is cached until refresh", func(t *testing.T) {
+		loads := 0
+		provider := NewProvider(func() ([]byte, error) {
+			loads++
+			return []byte(postgresSecret), nil
+		}, nil, "")
+
+		_, _ = provider.DSN()
+		_, _ = provider.DSN()
+		provider.Refresh()
+		_, _ = provider.DSN()
+
+		assert.Equal(t, 2, loads)
+	})
+}
+
+func TestIsAuthError(t *testing.T) {
+	assert.True(t, IsAuthError(errors.New(`pq: password authentication failed for user "app"`)))
+	assert.True(t, IsAuthError(errors.New("FATAL: password authentication failed (SQLSTATE 28P01)")))
+	assert.True(t, IsAuthError(errors.New("Error 1045 (28000): Access denied for user 'app'@'10.0.0.1'")))
+	assert.False(t, IsAuthError(errors.New("dial tcp: connection refused")))
+	assert.False(t, IsAuthError(nil))
+}
@@ -0,0 +1,180 @@
+package s3
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	s3bucket "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression identifica o algoritmo de compressão aplicado ao objeto S3
+type Compression string
+
+const (
+	CompressionNone  Compression = ""
+	CompressionGzip  Compression = "gzip"
+	CompressionZstd  Compression = "zstd"
+	CompressionBzip2 Compression = "bzip2"
+)
+
+var compressionExtensions = map[string]Compression{
+	".gz":   CompressionGzip,
+	".gzip": CompressionGzip,
+	".zst":  CompressionZstd,
+	".zstd": CompressionZstd,
+	".bz2":  CompressionBzip2,
+}
+
+var compressionMediaTypes = map[string]Compression{
+	"gzip":                CompressionGzip,
+	"x-gzip":              CompressionGzip,
+	"application/gzip":    CompressionGzip,
+	"application/x-gzip":  CompressionGzip,
+	"zstd":                CompressionZstd,
+	"application/zstd":    CompressionZstd,
+	"bzip2":               CompressionBzip2,
+	"x-bzip2":             CompressionBzip2,
+	"application/x-bzip2": CompressionBzip2,
+}
+
+// compressionMagic contém a assinatura inicial de cada formato comprimido
+var compressionMagic = map[Compression][]byte{
+	CompressionGzip:  {0x1f, 0x8b},
+	CompressionZstd:  {0x28, 0xb5, 0x2f, 0xfd},
+	CompressionBzip2: []byte("BZh"),
+}
+
+// objectContent representa o conteúdo já descomprimido de um objeto e os dados usados para
+// identificar o formato interno
+type objectContent struct {
+	io.ReadCloser
+	keyName     string
+	contentType string
+}
+
+// openContent descomprime o corpo do objeto quando o Content-Encoding, o Content-Type ou a
+// extensão composta (ex.: .json.gz) indicarem compressão
+func openContent(keyName string, result *s3bucket.GetObjectOutput) (*objectContent, error) {
+	contentType := aws.StringValue(result.ContentType)
+	compression, innerKey := detectCompression(keyName, aws.StringValue(result.ContentEncoding), contentType)
+	if compression == CompressionNone {
+		return &objectContent{ReadCloser: result.Body, keyName: keyName, contentType: contentType}, nil
+	}
+
+	// O Content-Type de um arquivo comprimido descreve o pacote e não o conteúdo interno
+	if _, ok := compressionMediaTypes[mediaType(contentType)]; ok {
+		contentType = ""
+	}
+
+	// O net/http descomprime sozinho respostas com Content-Encoding: gzip quando o cliente não
+	// envia Accept-Encoding, o que o SDK não faz; por isso o corpo só é descomprimido quando
+	// começa de fato com a assinatura do formato
+	body := &peekReader{Reader: bufio.NewReader(result.Body), body: result.Body}
+	if !body.hasPrefix(compressionMagic[compression]) {
+		return &objectContent{ReadCloser: body, keyName: innerKey, contentType: contentType}, nil
+	}
+
+	reader, err := decompress(body, compression)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	return &objectContent{ReadCloser: reader, keyName: innerKey, contentType: contentType}, nil
+}
+
+// peekReader permite inspecionar o início do corpo sem consumi-lo
+type peekReader struct {
+	*bufio.Reader
+	body io.Closer
+}
+
+func (r *peekReader) hasPrefix(prefix []byte) bool {
+	head, _ := r.Peek(len(prefix))
+	return bytes.Equal(head, prefix)
+}
+
+func (r *peekReader) Close() error {
+	return r.body.Close()
+}
+
+// detectCompression retorna o algoritmo de compressão e o nome do arquivo sem a extensão de compressão
+func detectCompression(keyName, contentEncoding, contentType string) (Compression, string) {
+	innerKey := keyName
+	extensionCompression := CompressionNone
+
+	lowerKey := strings.ToLower(keyName)
+	for extension, compression := range compressionExtensions {
+		if strings.HasSuffix(lowerKey, extension) {
+			innerKey = keyName[:len(keyName)-len(extension)]
+			extensionCompression = compression
+			break
+		}
+	}
+
+	for _, encoding := range strings.Split(contentEncoding, ",") {
+		if compression, ok := compressionMediaTypes[strings.ToLower(strings.TrimSpace(encoding))]; ok {
+			return compression, innerKey
+		}
+	}
+	if compression, ok := compressionMediaTypes[mediaType(contentType)]; ok {
+		return compression, innerKey
+	}
+	return extensionCompression, innerKey
+}
+
+func mediaType(contentType string) string {
+	parsed, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return parsed
+}
+
+// decompress envolve o corpo do objeto em um leitor que descomprime sob demanda
+func decompress(body io.ReadCloser, compression Compression) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionGzip:
+		reader, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, fmt.Errorf("error when decompressing gzip content: %w", err)
+		}
+		return &decompressReader{Reader: reader, closers: []io.Closer{reader, body}}, nil
+
+	case CompressionZstd:
+		reader, err := zstd.NewReader(body)
+		if err != nil {
+			return nil, fmt.Errorf("error when decompressing zstd content: %w", err)
+		}
+		return &decompressReader{Reader: reader, closers: []io.Closer{reader.IOReadCloser(), body}}, nil
+
+	case CompressionBzip2:
+		return &decompressReader{Reader: bzip2.NewReader(body), closers: []io.Closer{body}}, nil
+
+	default:
+		return body, nil
+	}
+}
+
+// decompressReader fecha o descompressor e o corpo original do objeto
+type decompressReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *decompressReader) Close() error {
+	var firstErr error
+	for _, closer := range r.closers {
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,154 @@
+package s3
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// bzip2Content contém "name: test\n" comprimido com bzip2, já que a biblioteca padrão só descomprime
+var bzip2Content = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0xbf, 0x4f, 0xf8, 0xca, 0x00, 0x00,
+	0x03, 0xd9, 0x80, 0x00, 0x10, 0x40, 0x00, 0x00, 0x10, 0x22, 0x03, 0x0c, 0x00, 0x20, 0x00, 0x31,
+	0x06, 0x4c, 0x41, 0x00, 0xd3, 0xd2, 0x3c, 0x41, 0xa7, 0x26, 0xfc, 0x5d, 0xc9, 0x14, 0xe1, 0x42,
+	0x42, 0xfd, 0x3f, 0xe3, 0x28,
+}
+
+func gzipContent(t *testing.T, content string) []byte {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err := writer.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func zstdContent(t *testing.T, content string) []byte {
+	encoder, err := zstd.NewWriter(nil)
+	assert.NoError(t, err)
+	defer encoder.Close()
+	return encoder.EncodeAll([]byte(content), nil)
+}
+
+func TestS3CloudContext_Decompression(t *testing.T) {
+	t.Run("Decompress gzip by extension", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body:        io.NopCloser(bytes.NewReader(gzipContent(t, `{"name": "test"}`))),
+			ContentType: aws.String("application/gzip"),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "features.json.gz")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"name": "test"}, result)
+	})
+
+	t.Run("Decompress gzip by content encoding", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body:            io.NopCloser(bytes.NewReader(gzipContent(t, "name: test\n"))),
+			ContentEncoding: aws.String("gzip"),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "features.yaml")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"name": "test"}, result)
+	})
+
+	t.Run("Decompress zstd CSV", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader(zstdContent(t, "id,rule\n1,allow\n"))),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "rules.csv.zst")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]string{{"id": "1", "rule": "allow"}}, result)
+	})
+
+	t.Run("Decompress bzip2", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader(bzip2Content)),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "settings.YML.bz2")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"name": "test"}, result)
+	})
+
+	t.Run("Iterate compressed CSV", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader(gzipContent(t, "id,name\n1,Alice\n"))),
+		}, nil)
+
+		it, err := ctx.NewCSVIterator("test-bucket", "export.csv.gz")
+		assert.NoError(t, err)
+		defer it.Close()
+
+		row, err := it.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, "Alice", row["name"])
+
+		_, err = it.Next()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("Invalid content with gzip extension", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader([]byte("not compressed"))),
+		}, nil)
+
+		_, err := ctx.GetValue("test-bucket", "features.json.gz")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Gzip content encoding through HTTP transport", func(t *testing.T) {
+		// O net/http remove o Content-Encoding e entrega o corpo já descomprimido
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write(gzipContent(t, `{"name": "test"}`))
+		}))
+		defer server.Close()
+
+		sess, err := session.NewSession(&aws.Config{
+			Region:           aws.String("us-east-1"),
+			Endpoint:         aws.String(server.URL),
+			S3ForcePathStyle: aws.Bool(true),
+			Credentials:      credentials.NewStaticCredentials("AKID", "SECRET", ""),
+			HTTPClient:       &http.Client{Transport: &http.Transport{}},
+		})
+		assert.NoError(t, err)
+
+		httpCtx := &S3CloudContext{svc: s3.New(sess)}
+		result, err := httpCtx.GetValue("test-bucket", "features.json.gz")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"name": "test"}, result)
+	})
+}
@@ -1,94 +1,114 @@
 package s3
 
 import (
-	"encoding/csv"
-	"encoding/json"
 	"fmt"
 	"io"
-	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
 	s3bucket "github.com/aws/aws-sdk-go/service/s3"
-	"gopkg.in/yaml.v3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 )
 
 type S3Resource interface {
 	GetObject(input *s3bucket.GetObjectInput) (*s3bucket.GetObjectOutput, error)
+	HeadObject(input *s3bucket.HeadObjectInput) (*s3bucket.HeadObjectOutput, error)
+	PutObject(input *s3bucket.PutObjectInput) (*s3bucket.PutObjectOutput, error)
+	GetObjectTagging(input *s3bucket.GetObjectTaggingInput) (*s3bucket.GetObjectTaggingOutput, error)
+	ListObjectVersions(input *s3bucket.ListObjectVersionsInput) (*s3bucket.ListObjectVersionsOutput, error)
+	ListObjectsV2(input *s3bucket.ListObjectsV2Input) (*s3bucket.ListObjectsV2Output, error)
+	SelectObjectContent(input *s3bucket.SelectObjectContentInput) (*s3bucket.SelectObjectContentOutput, error)
+	GetObjectRequest(input *s3bucket.GetObjectInput) (*request.Request, *s3bucket.GetObjectOutput)
+	PutObjectRequest(input *s3bucket.PutObjectInput) (*request.Request, *s3bucket.PutObjectOutput)
 }
 
 // S3CloudContext implements CloudContext para S3
 type S3CloudContext struct {
-	svc S3Resource
+	svc      S3Resource
+	uploader S3Uploader
+	config   *aws.Config
+	cache    objectCache
+
+	kms KMSResource
+
+	// mutex protege as configurações de criptografia e assinatura, alteráveis em tempo de execução
+	mutex      sync.RWMutex
+	encryption *encryptionConfig
+	signature  *signatureConfig
 }
 
 func NewS3Context(sess *session.Session) *S3CloudContext {
+	svc := s3bucket.New(sess)
 	return &S3CloudContext{
-		svc: s3bucket.New(sess),
+		svc:      svc,
+		uploader: s3manager.NewUploaderWithClient(svc),
+		config:   sess.Config,
+		kms:      kms.New(sess),
 	}
 }
 
 // GetValue obtém o conteúdo do arquivo S3 e o converte para o formato apropriado
-func (ctx *S3CloudContext) GetValue(bucketName, keyName string) (interface{}, error) {
-	input := &s3bucket.GetObjectInput{
-		Bucket: aws.String(bucketName),
-		Key:    aws.String(keyName),
+func (ctx *S3CloudContext) GetValue(bucketName, keyName string, opts ...GetOption) (interface{}, error) {
+	object, err := ctx.GetObject(bucketName, keyName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return object.Value, nil
+}
+
+// readContent lê todo o conteúdo do objeto, já verificado, decifrado e descomprimido
+func (ctx *S3CloudContext) readContent(bucketName, keyName, versionID string, result *s3bucket.GetObjectOutput) (*objectContent, []byte, error) {
+	if err := ctx.verifyResult(bucketName, keyName, versionID, result); err != nil {
+		return nil, nil, err
+	}
+	if err := ctx.decryptResult(bucketName, keyName, result); err != nil {
+		return nil, nil, err
 	}
 
-	result, err := ctx.svc.GetObject(input)
+	content, err := openContent(keyName, result)
 	if err != nil {
-		return nil, fmt.Errorf("error when obtaining S3 object: %w", err)
+		return nil, nil, err
 	}
-	defer result.Body.Close()
+	defer content.Close()
 
 	// Ler o conteúdo do arquivo
-	bodyBytes, err := io.ReadAll(result.Body)
+	bodyBytes, err := io.ReadAll(content)
 	if err != nil {
-		return nil, fmt.Errorf("error reading file content: %w", err)
+		return nil, nil, fmt.Errorf("error reading file content: %w", err)
+	}
+	return content, bodyBytes, nil
+}
+
+// decodeObject identifica o formato do conteúdo, decodifica e aplica a consulta JMESPath, se houver
+func decodeObject(content *objectContent, bodyBytes []byte, options *GetOptions) (interface{}, error) {
+	format := options.Format
+	if format == FormatAuto {
+		format = detectFormat(content.keyName, content.contentType, bodyBytes)
 	}
-	content := string(bodyBytes)
-
-	// Determinar o tipo de arquivo a processar de acordo
-	if strings.HasSuffix(keyName, ".json") {
-		var jsonData map[string]interface{}
-		if err := json.Unmarshal(bodyBytes, &jsonData); err != nil {
-			return nil, fmt.Errorf("error when analyzing JSON: %w", err)
-		}
-		return jsonData, nil
-
-	} else if strings.HasSuffix(keyName, ".yaml") || strings.HasSuffix(keyName, ".yml") {
-		var yamlData map[string]interface{}
-		if err := yaml.Unmarshal(bodyBytes, &yamlData); err != nil {
-			return nil, fmt.Errorf("error when analyzing yaml: %w", err)
-		}
-		return yamlData, nil
-
-	} else if strings.HasSuffix(keyName, ".csv") {
-		reader := csv.NewReader(strings.NewReader(content))
-		records, err := reader.ReadAll()
-		if err != nil {
-			return nil, fmt.Errorf("error when analyzing CSV: %w", err)
-		}
-
-		// Converter CSV para mapa
-		if len(records) < 2 {
-			return records, nil // Retorna registros crus se não houver cabeçalho
-		}
-
-		headers := records[0]
-		result := make([]map[string]string, 0, len(records)-1)
-
-		for i := 1; i < len(records); i++ {
-			row := make(map[string]string)
-			for j := 0; j < len(headers) && j < len(records[i]); j++ {
-				row[headers[j]] = records[i][j]
-			}
-			result = append(result, row)
-		}
-		return result, nil
-
-	} else {
-		// Assumir que é um arquivo de teste simples
-		return content, nil
+
+	value, err := decodeContent(format, bodyBytes, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Query != "" {
+		return applyQuery(options.Query, value)
+	}
+	return value, nil
+}
+
+// decodeContent converte o conteúdo do objeto utilizando o Decoder registrado para o formato
+func decodeContent(format Format, bodyBytes []byte, options *GetOptions) (interface{}, error) {
+	if format == FormatCSV && options.CSV != nil {
+		return decodeCSVWithOptions(bodyBytes, *options.CSV)
+	}
+
+	decoder, ok := lookupDecoder(format)
+	if !ok {
+		return nil, fmt.Errorf("unsupported format: %s", format)
 	}
+	return decoder.Decode(bodyBytes)
 }
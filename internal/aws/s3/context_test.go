@@ -3,9 +3,13 @@ package s3
 import (
 	"bytes"
 	"io"
+	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -19,15 +23,67 @@ func (m *mockS3Client) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput,
 	return args.Get(0).(*s3.GetObjectOutput), args.Error(1)
 }
 
+func (m *mockS3Client) HeadObject(input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*s3.HeadObjectOutput), args.Error(1)
+}
+
+func (m *mockS3Client) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*s3.PutObjectOutput), args.Error(1)
+}
+
+func (m *mockS3Client) GetObjectTagging(input *s3.GetObjectTaggingInput) (*s3.GetObjectTaggingOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*s3.GetObjectTaggingOutput), args.Error(1)
+}
+
+func (m *mockS3Client) ListObjectVersions(input *s3.ListObjectVersionsInput) (*s3.ListObjectVersionsOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*s3.ListObjectVersionsOutput), args.Error(1)
+}
+
+func (m *mockS3Client) ListObjectsV2(input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+	args := m.Called(input)
+	return args.Get(0).(*s3.ListObjectsV2Output), args.Error(1)
+}
+
+func (m *mockS3Client) SelectObjectContent(input *s3.SelectObjectContentInput) (*s3.SelectObjectContentOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*s3.SelectObjectContentOutput), args.Error(1)
+}
+
+func (m *mockS3Client) GetObjectRequest(input *s3.GetObjectInput) (*request.Request, *s3.GetObjectOutput) {
+	args := m.Called(input)
+	return args.Get(0).(*request.Request), args.Get(1).(*s3.GetObjectOutput)
+}
+
+func (m *mockS3Client) PutObjectRequest(input *s3.PutObjectInput) (*request.Request, *s3.PutObjectOutput) {
+	args := m.Called(input)
+	return args.Get(0).(*request.Request), args.Get(1).(*s3.PutObjectOutput)
+}
+
+type mockS3Uploader struct {
+	mock.Mock
+}
+
+func (m *mockS3Uploader) Upload(input *s3manager.UploadInput, options ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error) {
+	args := m.Called(input, options)
+	return args.Get(0).(*s3manager.UploadOutput), args.Error(1)
+}
+
 var (
-	mockS3 *mockS3Client
-	ctx    *S3CloudContext
+	mockS3       *mockS3Client
+	mockUploader *mockS3Uploader
+	ctx          *S3CloudContext
 )
 
 func loadDefaultVariables() {
 	mockS3 = new(mockS3Client)
+	mockUploader = new(mockS3Uploader)
 	ctx = &S3CloudContext{
-		svc: mockS3,
+		svc:      mockS3,
+		uploader: mockUploader,
 	}
 }
 
@@ -126,3 +182,327 @@ list:
 		assert.Equal(t, textContent, textResult)
 	})
 }
+
+func TestS3CloudContext_GetValueWithQuery(t *testing.T) {
+	t.Run("Query JSON object", func(t *testing.T) {
+		loadDefaultVariables()
+
+		jsonContent := `{"features": {"checkout": {"enabled": true, "limit": 10}}}`
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader([]byte(jsonContent))),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "flags.json", WithQuery("features.checkout.limit"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, float64(10), result)
+	})
+
+	t.Run("Query YAML object", func(t *testing.T) {
+		loadDefaultVariables()
+
+		yamlContent := `tenants:
+  - name: alpha
+    plan: gold
+  - name: beta
+    plan: silver`
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader([]byte(yamlContent))),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "tenants.yaml", WithQuery("tenants[?plan=='gold'].name"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{"alpha"}, result)
+	})
+
+	t.Run("Query CSV object", func(t *testing.T) {
+		loadDefaultVariables()
+
+		csvContent := `id,name,age
+1,Alice,30
+2,Bob,25`
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader([]byte(csvContent))),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "people.csv", WithQuery("[?name=='Bob'].age | [0]"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "25", result)
+	})
+
+	t.Run("Query plain text object", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader([]byte("plain text"))),
+		}, nil)
+
+		_, err := ctx.GetValue("test-bucket", "notes.txt", WithQuery("name"))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid expression", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader([]byte(`{"name": "test"}`))),
+		}, nil)
+
+		_, err := ctx.GetValue("test-bucket", "test-file.json", WithQuery("name[?"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestS3CloudContext_PutValue(t *testing.T) {
+	t.Run("Put JSON object with encryption, metadata and tags", func(t *testing.T) {
+		loadDefaultVariables()
+
+		var captured *s3.PutObjectInput
+		mockS3.On("PutObject", mock.Anything).Run(func(args mock.Arguments) {
+			captured = args.Get(0).(*s3.PutObjectInput)
+		}).Return(&s3.PutObjectOutput{
+			ETag:      aws.String(`"abc123"`),
+			VersionId: aws.String("v1"),
+		}, nil)
+
+		result, err := ctx.PutValue("test-bucket", "report.json", map[string]interface{}{"total": 10},
+			WithSSEKMS("alias/reports"),
+			WithMetadata(map[string]string{"origin": "batch"}),
+			WithTags(map[string]string{"team": "data"}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, `"abc123"`, result.ETag)
+		assert.Equal(t, "v1", result.VersionID)
+		assert.Equal(t, "application/json", aws.StringValue(captured.ContentType))
+		assert.Equal(t, "aws:kms", aws.StringValue(captured.ServerSideEncryption))
+		assert.Equal(t, "alias/reports", aws.StringValue(captured.SSEKMSKeyId))
+		assert.Equal(t, "batch", aws.StringValue(captured.Metadata["origin"]))
+		assert.Equal(t, "team=data", aws.StringValue(captured.Tagging))
+
+		body, _ := io.ReadAll(captured.Body)
+		assert.JSONEq(t, `{"total": 10}`, string(body))
+	})
+
+	t.Run("Put YAML object", func(t *testing.T) {
+		loadDefaultVariables()
+
+		var captured *s3.PutObjectInput
+		mockS3.On("PutObject", mock.Anything).Run(func(args mock.Arguments) {
+			captured = args.Get(0).(*s3.PutObjectInput)
+		}).Return(&s3.PutObjectOutput{}, nil)
+
+		_, err := ctx.PutValue("test-bucket", "state.yml", map[string]interface{}{"name": "test"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "application/yaml", aws.StringValue(captured.ContentType))
+
+		body, _ := io.ReadAll(captured.Body)
+		assert.Equal(t, "name: test\n", string(body))
+	})
+
+	t.Run("Put CSV object from maps", func(t *testing.T) {
+		loadDefaultVariables()
+
+		var captured *s3.PutObjectInput
+		mockS3.On("PutObject", mock.Anything).Run(func(args mock.Arguments) {
+			captured = args.Get(0).(*s3.PutObjectInput)
+		}).Return(&s3.PutObjectOutput{}, nil)
+
+		_, err := ctx.PutValue("test-bucket", "people.csv", []map[string]string{
+			{"name": "Alice", "age": "30"},
+			{"name": "Bob", "age": "25"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "text/csv", aws.StringValue(captured.ContentType))
+
+		body, _ := io.ReadAll(captured.Body)
+		assert.Equal(t, "age,name\n30,Alice\n25,Bob\n", string(body))
+	})
+
+	t.Run("Put unsupported value", func(t *testing.T) {
+		loadDefaultVariables()
+
+		_, err := ctx.PutValue("test-bucket", "data.bin", 42)
+
+		assert.Error(t, err)
+		mockS3.AssertNotCalled(t, "PutObject", mock.Anything)
+	})
+}
+
+func TestS3CloudContext_Streaming(t *testing.T) {
+	t.Run("Get ranged reader", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+			return aws.StringValue(input.Range) == "bytes=10-19"
+		})).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(strings.NewReader("0123456789")),
+		}, nil)
+
+		body, err := ctx.GetRange("test-bucket", "export.csv", 10, 10)
+
+		assert.NoError(t, err)
+		defer body.Close()
+		content, _ := io.ReadAll(body)
+		assert.Equal(t, "0123456789", string(content))
+	})
+
+	t.Run("Iterate CSV rows", func(t *testing.T) {
+		loadDefaultVariables()
+
+		csvContent := `id,name
+1,Alice
+2,Bob`
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(strings.NewReader(csvContent)),
+		}, nil)
+
+		it, err := ctx.NewCSVIterator("test-bucket", "export.csv")
+		assert.NoError(t, err)
+		defer it.Close()
+
+		assert.Equal(t, []string{"id", "name"}, it.Headers())
+
+		names := make([]string, 0)
+		for {
+			row, err := it.Next()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			names = append(names, row["name"])
+		}
+		assert.Equal(t, []string{"Alice", "Bob"}, names)
+	})
+
+	t.Run("Decode NDJSON records", func(t *testing.T) {
+		loadDefaultVariables()
+
+		ndjsonContent := "{\"id\": 1}\n{\"id\": 2}\n"
+		mockS3.On("GetObject", mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+			return aws.StringValue(input.VersionId) == "v1"
+		})).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(strings.NewReader(ndjsonContent)),
+		}, nil)
+
+		decoder, err := ctx.NewNDJSONDecoder("test-bucket", "events.ndjson", WithVersionID("v1"))
+		assert.NoError(t, err)
+		defer decoder.Close()
+
+		ids := make([]int, 0)
+		for {
+			var record struct {
+				ID int `json:"id"`
+			}
+			if err := decoder.Next(&record); err == io.EOF {
+				break
+			} else {
+				assert.NoError(t, err)
+			}
+			ids = append(ids, record.ID)
+		}
+		assert.Equal(t, []int{1, 2}, ids)
+	})
+
+	t.Run("Multipart upload", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockUploader.On("Upload", mock.MatchedBy(func(input *s3manager.UploadInput) bool {
+			return aws.StringValue(input.ContentType) == "text/csv"
+		}), mock.Anything).Run(func(args mock.Arguments) {
+			options := args.Get(1).([]func(*s3manager.Uploader))
+			uploader := &s3manager.Uploader{}
+			for _, opt := range options {
+				opt(uploader)
+			}
+			assert.Equal(t, int64(10*1024*1024), uploader.PartSize)
+			assert.Equal(t, 3, uploader.Concurrency)
+		}).Return(&s3manager.UploadOutput{
+			ETag:      aws.String(`"etag-1"`),
+			VersionID: aws.String("v2"),
+		}, nil)
+
+		result, err := ctx.Upload("test-bucket", "export.csv", strings.NewReader("id\n1\n"),
+			WithContentType("text/csv"),
+			WithPartSize(10*1024*1024),
+			WithConcurrency(3))
+
+		assert.NoError(t, err)
+		assert.Equal(t, `"etag-1"`, result.ETag)
+		assert.Equal(t, "v2", result.VersionID)
+	})
+}
+
+func TestS3CloudContext_FormatDetection(t *testing.T) {
+	t.Run("Detect uppercase extension", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(strings.NewReader(`{"name": "test"}`)),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "data.JSON")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"name": "test"}, result)
+	})
+
+	t.Run("Detect by content type", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body:        io.NopCloser(strings.NewReader("id,name\n1,Alice")),
+			ContentType: aws.String("text/csv; charset=utf-8"),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "export")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]string{{"id": "1", "name": "Alice"}}, result)
+	})
+
+	t.Run("Sniff JSON payload", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body:        io.NopCloser(strings.NewReader(`[{"id": 1}]`)),
+			ContentType: aws.String("application/octet-stream"),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "config")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{map[string]interface{}{"id": float64(1)}}, result)
+	})
+
+	t.Run("Sniff YAML payload", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(strings.NewReader("# settings\nname: test\nretries: 3\n")),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "config")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"name": "test", "retries": 3}, result)
+	})
+
+	t.Run("Explicit format override", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(strings.NewReader(`{"name": "test"}`)),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "data.json", WithFormat(FormatText))
+
+		assert.NoError(t, err)
+		assert.Equal(t, `{"name": "test"}`, result)
+	})
+}
@@ -0,0 +1,237 @@
+package s3
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ColumnType define o tipo Go para o qual uma coluna CSV é convertida
+type ColumnType string
+
+const (
+	ColumnString ColumnType = "string"
+	ColumnInt    ColumnType = "int"
+	ColumnFloat  ColumnType = "float"
+	ColumnBool   ColumnType = "bool"
+	ColumnTime   ColumnType = "time"
+)
+
+// CSVOptions configura a interpretação de objetos CSV
+type CSVOptions struct {
+	// Delimiter é o separador de campos; o padrão é ','
+	Delimiter rune
+	// Comment indica o caractere que inicia linhas de comentário
+	Comment rune
+	// NoHeader indica que a primeira linha já contém dados
+	NoHeader bool
+	// Headers nomeia as colunas de arquivos sem cabeçalho
+	Headers []string
+	// LazyQuotes aceita aspas fora do padrão RFC 4180; não é suportado pelo S3 Select
+	LazyQuotes bool
+	// TrimLeadingSpace ignora espaços no início de cada campo; não é suportado pelo S3 Select
+	TrimLeadingSpace bool
+	// AllowRaggedRows aceita linhas com menos campos que o cabeçalho, preenchendo-as com vazio;
+	// não é suportado pelo S3 Select
+	AllowRaggedRows bool
+	// Columns converte as colunas informadas para o tipo declarado
+	Columns map[string]ColumnType
+	// TimeLayout é o layout das colunas do tipo time; o padrão é time.RFC3339
+	TimeLayout string
+}
+
+// WithCSVOptions configura a interpretação de objetos CSV
+func WithCSVOptions(csvOptions CSVOptions) GetOption {
+	return func(o *GetOptions) {
+		o.CSV = &csvOptions
+	}
+}
+
+// csvParser lê registros CSV aplicando as opções de cabeçalho, validação e tipagem
+type csvParser struct {
+	reader  *csv.Reader
+	options CSVOptions
+	headers []string
+}
+
+// newCSVParser prepara o leitor, remove o BOM UTF-8 e lê o cabeçalho quando existir;
+// retorna io.EOF quando o conteúdo está vazio
+func newCSVParser(r io.Reader, options CSVOptions) (*csvParser, error) {
+	buffered := bufio.NewReader(r)
+	if prefix, err := buffered.Peek(len(utf8BOM)); err == nil && bytes.Equal(prefix, utf8BOM) {
+		buffered.Discard(len(utf8BOM))
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.Comment = options.Comment
+	reader.LazyQuotes = options.LazyQuotes
+	reader.TrimLeadingSpace = options.TrimLeadingSpace
+	if options.Delimiter != 0 {
+		reader.Comma = options.Delimiter
+	}
+
+	parser := &csvParser{
+		reader:  reader,
+		options: options,
+		headers: options.Headers,
+	}
+
+	if options.AllowRaggedRows {
+		reader.FieldsPerRecord = -1
+	} else if len(parser.headers) > 0 {
+		reader.FieldsPerRecord = len(parser.headers)
+	}
+
+	if !options.NoHeader && len(options.Headers) == 0 {
+		headers, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("error when analyzing CSV: %w", err)
+		}
+		parser.headers = headers
+	}
+
+	for column := range options.Columns {
+		if !slices.Contains(parser.headers, column) {
+			return nil, fmt.Errorf("error when analyzing CSV: column %q not found in header", column)
+		}
+	}
+	return parser, nil
+}
+
+// next retorna o próximo registro e a linha em que ele começa no arquivo
+func (p *csvParser) next() ([]string, int, error) {
+	record, err := p.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, 0, io.EOF
+		}
+		return nil, 0, fmt.Errorf("error when analyzing CSV: %w", err)
+	}
+
+	line, _ := p.reader.FieldPos(0)
+	if p.headers != nil && len(record) > len(p.headers) {
+		return nil, line, fmt.Errorf("error when analyzing CSV: record on line %d: expected %d fields, found %d",
+			line, len(p.headers), len(record))
+	}
+	return record, line, nil
+}
+
+// toStrings associa cada campo do registro ao nome da coluna
+func (p *csvParser) toStrings(record []string) map[string]string {
+	row := make(map[string]string, len(p.headers))
+	for i, header := range p.headers {
+		if i < len(record) {
+			row[header] = record[i]
+		} else {
+			row[header] = ""
+		}
+	}
+	return row
+}
+
+// toValues associa cada campo ao nome da coluna, convertendo as colunas tipadas;
+// campos tipados vazios resultam em nil
+func (p *csvParser) toValues(record []string, line int) (map[string]interface{}, error) {
+	row := make(map[string]interface{}, len(p.headers))
+	for i, header := range p.headers {
+		cell := ""
+		if i < len(record) {
+			cell = record[i]
+		}
+
+		columnType, typed := p.options.Columns[header]
+		if !typed || columnType == ColumnString {
+			row[header] = cell
+			continue
+		}
+		if cell == "" {
+			row[header] = nil
+			continue
+		}
+
+		value, err := convertCell(cell, columnType, p.options.TimeLayout)
+		if err != nil {
+			return nil, fmt.Errorf("error when analyzing CSV: record on line %d, column %q: %w", line, header, err)
+		}
+		row[header] = value
+	}
+	return row, nil
+}
+
+func convertCell(cell string, columnType ColumnType, timeLayout string) (interface{}, error) {
+	switch columnType {
+	case ColumnInt:
+		return strconv.ParseInt(cell, 10, 64)
+	case ColumnFloat:
+		return strconv.ParseFloat(cell, 64)
+	case ColumnBool:
+		return strconv.ParseBool(cell)
+	case ColumnTime:
+		if timeLayout == "" {
+			timeLayout = time.RFC3339
+		}
+		return time.Parse(timeLayout, cell)
+	default:
+		return nil, fmt.Errorf("unsupported column type: %s", columnType)
+	}
+}
+
+// decodeCSVWithOptions retorna sempre o mesmo formato para a mesma configuração:
+// []map[string]string quando há cabeçalho, []map[string]interface{} quando há colunas
+// tipadas e [][]string para arquivos sem cabeçalho nem nomes de colunas
+func decodeCSVWithOptions(body []byte, options CSVOptions) (interface{}, error) {
+	parser, err := newCSVParser(bytes.NewReader(body), options)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	typed := len(options.Columns) > 0
+	records := make([][]string, 0)
+	rows := make([]map[string]string, 0)
+	values := make([]map[string]interface{}, 0)
+
+	for parser != nil {
+		record, line, err := parser.next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		switch {
+		case parser.headers == nil:
+			records = append(records, record)
+		case typed:
+			row, err := parser.toValues(record, line)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, row)
+		default:
+			rows = append(rows, parser.toStrings(record))
+		}
+	}
+
+	switch {
+	case options.NoHeader && len(options.Headers) == 0:
+		return records, nil
+	case typed:
+		return values, nil
+	default:
+		return rows, nil
+	}
+}
+
+func decodeCSV(body []byte) (interface{}, error) {
+	return decodeCSVWithOptions(body, CSVOptions{})
+}
@@ -0,0 +1,147 @@
+package s3
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDecodeCSVWithOptions(t *testing.T) {
+	t.Run("Semicolon delimiter, comments and BOM", func(t *testing.T) {
+		content := "\xEF\xBB\xBFid;name\n# ignored\n1;Alice\n"
+
+		result, err := decodeCSVWithOptions([]byte(content), CSVOptions{Delimiter: ';', Comment: '#'})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]string{{"id": "1", "name": "Alice"}}, result)
+	})
+
+	t.Run("Header only file keeps the map shape", func(t *testing.T) {
+		result, err := decodeCSVWithOptions([]byte("id,name\n"), CSVOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]string{}, result)
+	})
+
+	t.Run("Headerless file", func(t *testing.T) {
+		result, err := decodeCSVWithOptions([]byte("1,Alice\n2,Bob\n"), CSVOptions{NoHeader: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"1", "Alice"}, {"2", "Bob"}}, result)
+	})
+
+	t.Run("Headerless file with column names", func(t *testing.T) {
+		result, err := decodeCSVWithOptions([]byte("1,Alice\n"), CSVOptions{NoHeader: true, Headers: []string{"id", "name"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]string{{"id": "1", "name": "Alice"}}, result)
+	})
+
+	t.Run("Typed columns", func(t *testing.T) {
+		content := "id,score,active,created,name\n1,9.5,true,2024-01-02T03:04:05Z,Alice\n2,,false,2024-02-03T00:00:00Z,Bob\n"
+
+		result, err := decodeCSVWithOptions([]byte(content), CSVOptions{
+			Columns: map[string]ColumnType{
+				"id":      ColumnInt,
+				"score":   ColumnFloat,
+				"active":  ColumnBool,
+				"created": ColumnTime,
+			},
+		})
+
+		assert.NoError(t, err)
+		rows := result.([]map[string]interface{})
+		assert.Len(t, rows, 2)
+		assert.Equal(t, int64(1), rows[0]["id"])
+		assert.Equal(t, 9.5, rows[0]["score"])
+		assert.Equal(t, true, rows[0]["active"])
+		assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), rows[0]["created"])
+		assert.Equal(t, "Alice", rows[0]["name"])
+		assert.Nil(t, rows[1]["score"])
+	})
+
+	t.Run("Typed column error names the line", func(t *testing.T) {
+		content := "id,age\n1,30\n2,thirty\n"
+
+		_, err := decodeCSVWithOptions([]byte(content), CSVOptions{Columns: map[string]ColumnType{"age": ColumnInt}})
+
+		assert.ErrorContains(t, err, `record on line 3, column "age"`)
+	})
+
+	t.Run("Unknown typed column", func(t *testing.T) {
+		_, err := decodeCSVWithOptions([]byte("id\n1\n"), CSVOptions{Columns: map[string]ColumnType{"age": ColumnInt}})
+
+		assert.ErrorContains(t, err, `column "age" not found in header`)
+	})
+
+	t.Run("Ragged rows are rejected by default", func(t *testing.T) {
+		_, err := decodeCSVWithOptions([]byte("id,name\n1,Alice\n2\n"), CSVOptions{})
+
+		assert.ErrorContains(t, err, "line 3")
+	})
+
+	t.Run("Ragged rows allowed", func(t *testing.T) {
+		result, err := decodeCSVWithOptions([]byte("id,name\n1,Alice\n2\n"), CSVOptions{AllowRaggedRows: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]string{{"id": "1", "name": "Alice"}, {"id": "2", "name": ""}}, result)
+	})
+
+	t.Run("Rows longer than the header are rejected", func(t *testing.T) {
+		_, err := decodeCSVWithOptions([]byte("id,name\n1,Alice,extra\n"), CSVOptions{AllowRaggedRows: true})
+
+		assert.EqualError(t, err, "error when analyzing CSV: record on line 2: expected 2 fields, found 3")
+	})
+
+	t.Run("Lazy quotes", func(t *testing.T) {
+		result, err := decodeCSVWithOptions([]byte("id,name\n1,Al\"ice\n"), CSVOptions{LazyQuotes: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]string{{"id": "1", "name": "Al\"ice"}}, result)
+	})
+}
+
+func TestS3CloudContext_CSVOptions(t *testing.T) {
+	t.Run("Get CSV object with options", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(strings.NewReader("id\tage\n1\t30\n")),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "people.csv", WithCSVOptions(CSVOptions{
+			Delimiter: '\t',
+			Columns:   map[string]ColumnType{"age": ColumnInt},
+		}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]interface{}{{"id": "1", "age": int64(30)}}, result)
+	})
+
+	t.Run("Iterate typed CSV rows", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(strings.NewReader("id;age\n1;30\n")),
+		}, nil)
+
+		it, err := ctx.NewCSVIterator("test-bucket", "people.csv", WithCSVOptions(CSVOptions{
+			Delimiter: ';',
+			Columns:   map[string]ColumnType{"age": ColumnInt},
+		}))
+		assert.NoError(t, err)
+		defer it.Close()
+
+		row, err := it.NextValues()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(30), row["age"])
+
+		_, err = it.NextValues()
+		assert.Equal(t, io.EOF, err)
+	})
+}
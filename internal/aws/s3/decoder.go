@@ -0,0 +1,214 @@
+package s3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Decoder converte o conteúdo de um objeto S3 em um valor Go
+type Decoder interface {
+	Decode(body []byte) (interface{}, error)
+}
+
+// DecoderFunc permite utilizar uma função comum como Decoder
+type DecoderFunc func(body []byte) (interface{}, error)
+
+// Decode executa a função de decodificação
+func (f DecoderFunc) Decode(body []byte) (interface{}, error) {
+	return f(body)
+}
+
+// DecoderRegistration associa um Decoder às extensões e Content-Types que identificam o formato
+type DecoderRegistration struct {
+	Format       Format
+	Decoder      Decoder
+	Extensions   []string
+	ContentTypes []string
+}
+
+type decoderRegistry struct {
+	mutex        sync.RWMutex
+	decoders     map[Format]Decoder
+	extensions   map[string]Format
+	contentTypes map[string]Format
+}
+
+var registry = &decoderRegistry{
+	decoders:     make(map[Format]Decoder),
+	extensions:   make(map[string]Format),
+	contentTypes: make(map[string]Format),
+}
+
+func init() {
+	builtin := []DecoderRegistration{
+		{
+			Format:       FormatJSON,
+			Decoder:      DecoderFunc(decodeJSON),
+			Extensions:   []string{".json"},
+			ContentTypes: []string{"application/json", "text/json"},
+		},
+		{
+			Format:       FormatYAML,
+			Decoder:      DecoderFunc(decodeYAML),
+			Extensions:   []string{".yaml", ".yml"},
+			ContentTypes: []string{"application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml"},
+		},
+		{
+			Format:       FormatCSV,
+			Decoder:      DecoderFunc(decodeCSV),
+			Extensions:   []string{".csv"},
+			ContentTypes: []string{"text/csv", "application/csv"},
+		},
+		{
+			Format:     FormatText,
+			Decoder:    DecoderFunc(decodeText),
+			Extensions: []string{".txt"},
+		},
+		{
+			Format:       FormatTOML,
+			Decoder:      DecoderFunc(decodeTOML),
+			Extensions:   []string{".toml"},
+			ContentTypes: []string{"application/toml"},
+		},
+		{
+			Format:     FormatDotEnv,
+			Decoder:    DecoderFunc(decodeDotEnv),
+			Extensions: []string{".env"},
+		},
+		{
+			Format:       FormatProperties,
+			Decoder:      DecoderFunc(decodeProperties),
+			Extensions:   []string{".properties"},
+			ContentTypes: []string{"text/x-java-properties"},
+		},
+		{
+			Format:       FormatXML,
+			Decoder:      DecoderFunc(decodeXML),
+			Extensions:   []string{".xml"},
+			ContentTypes: []string{"application/xml", "text/xml"},
+		},
+		{
+			Format:       FormatNDJSON,
+			Decoder:      DecoderFunc(decodeNDJSON),
+			Extensions:   []string{".ndjson", ".jsonl"},
+			ContentTypes: []string{"application/x-ndjson", "application/ndjson", "application/jsonl"},
+		},
+	}
+
+	for _, registration := range builtin {
+		if err := RegisterDecoder(registration); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterDecoder registra um Decoder para o formato informado; registrar um formato
+// existente substitui o Decoder anterior, inclusive os formatos nativos
+func RegisterDecoder(registration DecoderRegistration) error {
+	if registration.Format == FormatAuto {
+		return errors.New("the decoder format is required")
+	}
+	if registration.Decoder == nil {
+		return fmt.Errorf("the decoder for format %s is nil", registration.Format)
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.decoders[registration.Format] = registration.Decoder
+	for _, extension := range registration.Extensions {
+		extension = strings.ToLower(extension)
+		if !strings.HasPrefix(extension, ".") {
+			extension = "." + extension
+		}
+		registry.extensions[extension] = registration.Format
+	}
+	for _, contentType := range registration.ContentTypes {
+		registry.contentTypes[strings.ToLower(contentType)] = registration.Format
+	}
+	return nil
+}
+
+// lookupDecoder retorna o Decoder registrado para o formato
+func lookupDecoder(format Format) (Decoder, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	decoder, ok := registry.decoders[format]
+	return decoder, ok
+}
+
+// formatFromExtension identifica o formato pela maior extensão registrada que termina o nome
+// do arquivo, sem diferenciar maiúsculas, permitindo extensões compostas como .pb.json
+func formatFromExtension(keyName string) Format {
+	keyName = strings.ToLower(keyName)
+
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	format, matched := FormatAuto, ""
+	for extension, candidate := range registry.extensions {
+		if strings.HasSuffix(keyName, extension) && len(extension) > len(matched) {
+			format, matched = candidate, extension
+		}
+	}
+	return format
+}
+
+// formatFromContentType identifica o formato pelo Content-Type gravado no objeto
+func formatFromContentType(contentType string) Format {
+	if contentType == "" {
+		return FormatAuto
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return FormatAuto
+	}
+
+	registry.mutex.RLock()
+	format, ok := registry.contentTypes[mediaType]
+	registry.mutex.RUnlock()
+	if ok {
+		return format
+	}
+
+	// text/plain e application/octet-stream são usados como padrão por muitos clientes
+	// e não dizem nada sobre o conteúdo, por isso seguem para a inspeção do payload
+	switch {
+	case strings.HasSuffix(mediaType, "+json"):
+		return FormatJSON
+	case strings.HasSuffix(mediaType, "+yaml"):
+		return FormatYAML
+	case strings.HasSuffix(mediaType, "+xml"):
+		return FormatXML
+	default:
+		return FormatAuto
+	}
+}
+
+func decodeJSON(body []byte) (interface{}, error) {
+	var jsonData interface{}
+	if err := json.Unmarshal(body, &jsonData); err != nil {
+		return nil, fmt.Errorf("error when analyzing JSON: %w", err)
+	}
+	return jsonData, nil
+}
+
+func decodeYAML(body []byte) (interface{}, error) {
+	var yamlData map[string]interface{}
+	if err := yaml.Unmarshal(body, &yamlData); err != nil {
+		return nil, fmt.Errorf("error when analyzing yaml: %w", err)
+	}
+	return yamlData, nil
+}
+
+func decodeText(body []byte) (interface{}, error) {
+	return string(body), nil
+}
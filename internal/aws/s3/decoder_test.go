@@ -0,0 +1,190 @@
+package s3
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDecoders(t *testing.T) {
+	t.Run("Decode TOML", func(t *testing.T) {
+		result, err := decodeTOML([]byte("name = \"test\"\n[limits]\nretries = 3\n"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"name":   "test",
+			"limits": map[string]interface{}{"retries": int64(3)},
+		}, result)
+	})
+
+	t.Run("Decode dotenv", func(t *testing.T) {
+		content := `# database
+export DB_HOST=localhost
+DB_PORT=5432 # default port
+DB_PASS="p@ss\nword"
+DB_USER='admin'
+`
+		result, err := decodeDotEnv([]byte(content))
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"DB_HOST": "localhost",
+			"DB_PORT": "5432",
+			"DB_PASS": "p@ss\nword",
+			"DB_USER": "admin",
+		}, result)
+	})
+
+	t.Run("Decode invalid dotenv", func(t *testing.T) {
+		_, err := decodeDotEnv([]byte("VALID=1\ninvalid line\n"))
+
+		assert.EqualError(t, err, "error when analyzing dotenv: invalid line 2")
+	})
+
+	t.Run("Decode properties", func(t *testing.T) {
+		content := `# comment
+! another comment
+app.name = checkout
+app.url: https://example.com
+app.owner team-a
+app.description = first \
+    second
+app.symbol = é
+`
+		result, err := decodeProperties([]byte(content))
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"app.name":        "checkout",
+			"app.url":         "https://example.com",
+			"app.owner":       "team-a",
+			"app.description": "first second",
+			"app.symbol":      "é",
+		}, result)
+	})
+
+	t.Run("Decode XML", func(t *testing.T) {
+		content := `<config env="prod"><name>test</name><host>a</host><host>b</host></config>`
+
+		result, err := decodeXML([]byte(content))
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"config": map[string]interface{}{
+				"@env": "prod",
+				"name": "test",
+				"host": []interface{}{"a", "b"},
+			},
+		}, result)
+	})
+
+	t.Run("Decode NDJSON", func(t *testing.T) {
+		result, err := decodeNDJSON([]byte("{\"id\": 1}\n{\"id\": 2}\n"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"id": float64(1)},
+			map[string]interface{}{"id": float64(2)},
+		}, result)
+	})
+}
+
+// restoreRegistry devolve o registro global de decoders ao estado anterior ao fim do teste
+func restoreRegistry(t *testing.T) {
+	registry.mutex.RLock()
+	decoders := make(map[Format]Decoder, len(registry.decoders))
+	for format, decoder := range registry.decoders {
+		decoders[format] = decoder
+	}
+	extensions := make(map[string]Format, len(registry.extensions))
+	for extension, format := range registry.extensions {
+		extensions[extension] = format
+	}
+	contentTypes := make(map[string]Format, len(registry.contentTypes))
+	for contentType, format := range registry.contentTypes {
+		contentTypes[contentType] = format
+	}
+	registry.mutex.RUnlock()
+
+	t.Cleanup(func() {
+		registry.mutex.Lock()
+		defer registry.mutex.Unlock()
+		registry.decoders = decoders
+		registry.extensions = extensions
+		registry.contentTypes = contentTypes
+	})
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	t.Run("Register custom decoder", func(t *testing.T) {
+		loadDefaultVariables()
+		restoreRegistry(t)
+
+		err := RegisterDecoder(DecoderRegistration{
+			Format: Format("protobuf-json"),
+			Decoder: DecoderFunc(func(body []byte) (interface{}, error) {
+				return "custom:" + string(body), nil
+			}),
+			Extensions:   []string{".pb.json"},
+			ContentTypes: []string{"application/x-protobuf+json"},
+		})
+		assert.NoError(t, err)
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader([]byte("{}"))),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "message.PB.JSON")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "custom:{}", result)
+	})
+
+	t.Run("Decode by registered content type", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body:        io.NopCloser(bytes.NewReader([]byte("name = \"test\""))),
+			ContentType: aws.String("application/toml"),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "settings")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"name": "test"}, result)
+	})
+
+	t.Run("Propagate decoder errors", func(t *testing.T) {
+		loadDefaultVariables()
+		restoreRegistry(t)
+
+		err := RegisterDecoder(DecoderRegistration{
+			Format: Format("failing"),
+			Decoder: DecoderFunc(func(body []byte) (interface{}, error) {
+				return nil, errors.New("boom")
+			}),
+		})
+		assert.NoError(t, err)
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader([]byte("{}"))),
+		}, nil)
+
+		_, err = ctx.GetValue("test-bucket", "data.json", WithFormat(Format("failing")))
+
+		assert.EqualError(t, err, "boom")
+	})
+
+	t.Run("Reject invalid registration", func(t *testing.T) {
+		restoreRegistry(t)
+
+		assert.Error(t, RegisterDecoder(DecoderRegistration{Decoder: DecoderFunc(decodeText)}))
+		assert.Error(t, RegisterDecoder(DecoderRegistration{Format: Format("empty")}))
+	})
+}
@@ -0,0 +1,246 @@
+package s3
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+func decodeTOML(body []byte) (interface{}, error) {
+	var tomlData map[string]interface{}
+	if err := toml.Unmarshal(body, &tomlData); err != nil {
+		return nil, fmt.Errorf("error when analyzing TOML: %w", err)
+	}
+	return tomlData, nil
+}
+
+// decodeDotEnv interpreta arquivos no formato KEY=VALUE, aceitando o prefixo export,
+// comentários e valores entre aspas simples ou duplas
+func decodeDotEnv(body []byte) (interface{}, error) {
+	result := make(map[string]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		key, value, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("error when analyzing dotenv: invalid line %d", lineNumber)
+		}
+
+		value = strings.TrimSpace(value)
+		switch {
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("error when analyzing dotenv: invalid value at line %d: %w", lineNumber, err)
+			}
+			value = unquoted
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		default:
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+		}
+		result[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error when analyzing dotenv: %w", err)
+	}
+	return result, nil
+}
+
+// decodeProperties interpreta arquivos .properties do Java, incluindo continuações de linha
+// e os separadores '=', ':' ou espaço
+func decodeProperties(body []byte) (interface{}, error) {
+	result := make(map[string]string)
+
+	lines := strings.Split(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		// Uma quantidade ímpar de barras no final indica continuação na próxima linha
+		for endsWithContinuation(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+
+		key, value := splitProperty(line)
+		unescapedKey, err := unescapeProperty(key)
+		if err != nil {
+			return nil, fmt.Errorf("error when analyzing properties: line %d: %w", i+1, err)
+		}
+		unescapedValue, err := unescapeProperty(value)
+		if err != nil {
+			return nil, fmt.Errorf("error when analyzing properties: line %d: %w", i+1, err)
+		}
+		result[unescapedKey] = unescapedValue
+	}
+	return result, nil
+}
+
+func endsWithContinuation(line string) bool {
+	count := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		count++
+	}
+	return count%2 == 1
+}
+
+func splitProperty(line string) (string, string) {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '=', ':':
+			return line[:i], strings.TrimLeft(line[i+1:], " \t\f")
+		case ' ', '\t', '\f':
+			rest := strings.TrimLeft(line[i:], " \t\f")
+			if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+				rest = strings.TrimLeft(rest[1:], " \t\f")
+			}
+			return line[:i], rest
+		}
+	}
+	return line, ""
+}
+
+func unescapeProperty(value string) (string, error) {
+	if !strings.Contains(value, "\\") {
+		return value, nil
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			builder.WriteByte(value[i])
+			continue
+		}
+
+		i++
+		switch value[i] {
+		case 't':
+			builder.WriteByte('\t')
+		case 'n':
+			builder.WriteByte('\n')
+		case 'r':
+			builder.WriteByte('\r')
+		case 'f':
+			builder.WriteByte('\f')
+		case 'u':
+			if i+4 >= len(value) {
+				return "", errors.New("malformed \\uXXXX escape")
+			}
+			code, err := strconv.ParseUint(value[i+1:i+5], 16, 32)
+			if err != nil {
+				return "", errors.New("malformed \\uXXXX escape")
+			}
+			builder.WriteRune(rune(code))
+			i += 4
+		default:
+			builder.WriteByte(value[i])
+		}
+	}
+	return builder.String(), nil
+}
+
+// decodeXML converte o documento em mapas aninhados: atributos recebem o prefixo '@',
+// elementos repetidos viram listas e o texto de elementos com filhos fica em '#text'
+func decodeXML(body []byte) (interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("error when analyzing XML: missing root element")
+			}
+			return nil, fmt.Errorf("error when analyzing XML: %w", err)
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := decodeXMLElement(decoder, start)
+			if err != nil {
+				return nil, fmt.Errorf("error when analyzing XML: %w", err)
+			}
+			return map[string]interface{}{start.Name.Local: value}, nil
+		}
+	}
+}
+
+func decodeXMLElement(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	element := make(map[string]interface{})
+	for _, attr := range start.Attr {
+		element["@"+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+			name := t.Name.Local
+			switch existing := element[name].(type) {
+			case nil:
+				element[name] = child
+			case []interface{}:
+				element[name] = append(existing, child)
+			default:
+				element[name] = []interface{}{existing, child}
+			}
+
+		case xml.CharData:
+			text.Write(t)
+
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(element) == 0 {
+				return content, nil
+			}
+			if content != "" {
+				element["#text"] = content
+			}
+			return element, nil
+		}
+	}
+}
+
+func decodeNDJSON(body []byte) (interface{}, error) {
+	result := make([]interface{}, 0)
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	for {
+		var record interface{}
+		if err := decoder.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				return result, nil
+			}
+			return nil, fmt.Errorf("error when analyzing NDJSON: record %d: %w", len(result)+1, err)
+		}
+		result = append(result, record)
+	}
+}
@@ -0,0 +1,220 @@
+package s3
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	s3bucket "github.com/aws/aws-sdk-go/service/s3"
+)
+
+// Metadados gravados nos objetos cifrados pelo envelope de criptografia do cliente
+const (
+	metadataCipher            = "Cec-Cipher"
+	metadataKMSKeyID          = "Cec-Kms-Key-Id"
+	metadataEncryptedKey      = "Cec-Encrypted-Key"
+	metadataEncryptionContext = "Cec-Encryption-Context"
+
+	envelopeCipher = "AES-256-GCM"
+)
+
+// KMSResource representa as operações do KMS usadas na criptografia de envelope
+type KMSResource interface {
+	GenerateDataKey(input *kms.GenerateDataKeyInput) (*kms.GenerateDataKeyOutput, error)
+	Decrypt(input *kms.DecryptInput) (*kms.DecryptOutput, error)
+}
+
+// encryptionConfig define a chave KMS usada para gerar as chaves de dados dos objetos gravados
+type encryptionConfig struct {
+	keyID   string
+	context map[string]string
+}
+
+// EnableEncryption passa a cifrar no cliente todos os objetos gravados por PutValue, usando uma
+// chave de dados gerada pela chave KMS informada; o contexto de criptografia é opcional
+func (ctx *S3CloudContext) EnableEncryption(kmsKeyID string, encryptionContext map[string]string) error {
+	if kmsKeyID == "" {
+		return errors.New("the KMS key id is required to enable client-side encryption")
+	}
+	if ctx.kms == nil {
+		return errors.New("the s3 context has no KMS client to enable client-side encryption")
+	}
+
+	// O mapa é copiado para que alterações do chamador não afetem gravações em andamento
+	config := &encryptionConfig{keyID: kmsKeyID}
+	if len(encryptionContext) > 0 {
+		config.context = make(map[string]string, len(encryptionContext))
+		for key, value := range encryptionContext {
+			config.context[key] = value
+		}
+	}
+
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	ctx.encryption = config
+	return nil
+}
+
+// DisableEncryption volta a gravar os objetos sem criptografia no cliente; objetos cifrados
+// continuam sendo decifrados na leitura
+func (ctx *S3CloudContext) DisableEncryption() {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	ctx.encryption = nil
+}
+
+// encryptionSettings retorna a configuração de criptografia vigente; a configuração não é
+// alterada depois de criada, então pode ser usada sem o lock
+func (ctx *S3CloudContext) encryptionSettings() *encryptionConfig {
+	ctx.mutex.RLock()
+	defer ctx.mutex.RUnlock()
+	return ctx.encryption
+}
+
+// objectAAD vincula o conteúdo cifrado ao bucket e à chave do objeto, impedindo que o conteúdo
+// e seus metadados sejam copiados para outra chave e decifrados com sucesso
+func objectAAD(bucketName, keyName string) []byte {
+	// Nomes de bucket não contêm "/", então a concatenação não é ambígua
+	return []byte(bucketName + "/" + keyName)
+}
+
+// encrypt cifra o conteúdo com AES-GCM e retorna os metadados necessários para decifrá-lo
+func (ctx *S3CloudContext) encrypt(config *encryptionConfig, bucketName, keyName string, plaintext []byte) ([]byte, map[string]string, error) {
+	input := &kms.GenerateDataKeyInput{
+		KeyId:   aws.String(config.keyID),
+		KeySpec: aws.String(kms.DataKeySpecAes256),
+	}
+	if len(config.context) > 0 {
+		input.EncryptionContext = aws.StringMap(config.context)
+	}
+
+	dataKey, err := ctx.kms.GenerateDataKey(input)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error when generating KMS data key: %w", err)
+	}
+
+	gcm, err := newGCM(dataKey.Plaintext)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, fmt.Errorf("error when generating encryption nonce: %w", err)
+	}
+
+	metadata := map[string]string{
+		metadataCipher:       envelopeCipher,
+		metadataKMSKeyID:     aws.StringValue(dataKey.KeyId),
+		metadataEncryptedKey: base64.StdEncoding.EncodeToString(dataKey.CiphertextBlob),
+	}
+	if len(config.context) > 0 {
+		encodedContext, err := json.Marshal(config.context)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error when encoding encryption context: %w", err)
+		}
+		metadata[metadataEncryptionContext] = base64.StdEncoding.EncodeToString(encodedContext)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, objectAAD(bucketName, keyName)), metadata, nil
+}
+
+// decryptResult substitui o corpo do objeto pelo conteúdo decifrado quando os metadados indicam
+// que ele foi gravado com criptografia de envelope; o conteúdo só é aceito na chave em que foi gravado
+func (ctx *S3CloudContext) decryptResult(bucketName, keyName string, result *s3bucket.GetObjectOutput) error {
+	if metadataValue(result.Metadata, metadataCipher) == "" {
+		return nil
+	}
+	defer result.Body.Close()
+
+	if cipherName := metadataValue(result.Metadata, metadataCipher); cipherName != envelopeCipher {
+		return fmt.Errorf("unsupported client-side encryption cipher: %s", cipherName)
+	}
+	if ctx.kms == nil {
+		return errors.New("the s3 context has no KMS client to decrypt the object")
+	}
+
+	encryptedKey, err := base64.StdEncoding.DecodeString(metadataValue(result.Metadata, metadataEncryptedKey))
+	if err != nil {
+		return fmt.Errorf("error when decoding encrypted data key: %w", err)
+	}
+
+	input := &kms.DecryptInput{
+		CiphertextBlob: encryptedKey,
+	}
+	if keyID := metadataValue(result.Metadata, metadataKMSKeyID); keyID != "" {
+		input.KeyId = aws.String(keyID)
+	}
+	if encodedContext := metadataValue(result.Metadata, metadataEncryptionContext); encodedContext != "" {
+		decodedContext, err := base64.StdEncoding.DecodeString(encodedContext)
+		if err != nil {
+			return fmt.Errorf("error when decoding encryption context: %w", err)
+		}
+
+		var encryptionContext map[string]string
+		if err := json.Unmarshal(decodedContext, &encryptionContext); err != nil {
+			return fmt.Errorf("error when decoding encryption context: %w", err)
+		}
+		input.EncryptionContext = aws.StringMap(encryptionContext)
+	}
+
+	dataKey, err := ctx.kms.Decrypt(input)
+	if err != nil {
+		return fmt.Errorf("error when decrypting KMS data key: %w", err)
+	}
+
+	ciphertext, err := io.ReadAll(result.Body)
+	if err != nil {
+		return fmt.Errorf("error reading file content: %w", err)
+	}
+
+	gcm, err := newGCM(dataKey.Plaintext)
+	if err != nil {
+		return err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return errors.New("error when decrypting S3 object: content is too short")
+	}
+
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, objectAAD(bucketName, keyName))
+	if err != nil {
+		return fmt.Errorf("error when decrypting S3 object: %w", err)
+	}
+
+	result.Body = io.NopCloser(bytes.NewReader(plaintext))
+	result.ContentLength = aws.Int64(int64(len(plaintext)))
+	return nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating AES cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating AES-GCM cipher: %w", err)
+	}
+	return gcm, nil
+}
+
+// metadataValue busca um metadado sem diferenciar maiúsculas, já que o S3 normaliza os nomes
+func metadataValue(metadata map[string]*string, name string) string {
+	for key, value := range metadata {
+		if strings.EqualFold(key, name) {
+			return aws.StringValue(value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,219 @@
+package s3
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockKMSClient struct {
+	mock.Mock
+}
+
+func (m *mockKMSClient) GenerateDataKey(input *kms.GenerateDataKeyInput) (*kms.GenerateDataKeyOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*kms.GenerateDataKeyOutput), args.Error(1)
+}
+
+func (m *mockKMSClient) Decrypt(input *kms.DecryptInput) (*kms.DecryptOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*kms.DecryptOutput), args.Error(1)
+}
+
+var dataKey = bytes.Repeat([]byte{0x42}, 32)
+
+// putEncrypted grava o valor com criptografia de envelope e retorna o corpo e os metadados enviados ao S3
+func putEncrypted(t *testing.T, mockKMS *mockKMSClient, keyName string, value interface{}) ([]byte, map[string]*string) {
+	mockKMS.On("GenerateDataKey", mock.MatchedBy(func(input *kms.GenerateDataKeyInput) bool {
+		return aws.StringValue(input.KeyId) == "alias/config" &&
+			aws.StringValue(input.EncryptionContext["app"]) == "checkout"
+	})).Return(&kms.GenerateDataKeyOutput{
+		KeyId:          aws.String("arn:aws:kms:us-east-1:111122223333:key/config"),
+		Plaintext:      dataKey,
+		CiphertextBlob: []byte("encrypted-data-key"),
+	}, nil).Once()
+
+	var captured *s3.PutObjectInput
+	mockS3.On("PutObject", mock.Anything).Run(func(args mock.Arguments) {
+		captured = args.Get(0).(*s3.PutObjectInput)
+	}).Return(&s3.PutObjectOutput{}, nil).Once()
+
+	assert.NoError(t, ctx.EnableEncryption("alias/config", map[string]string{"app": "checkout"}))
+	_, err := ctx.PutValue("test-bucket", keyName, value)
+	assert.NoError(t, err)
+
+	body, _ := io.ReadAll(captured.Body)
+	return body, captured.Metadata
+}
+
+func TestS3CloudContext_Encryption(t *testing.T) {
+	t.Run("Encrypt on write and decrypt on read", func(t *testing.T) {
+		loadDefaultVariables()
+		mockKMS := new(mockKMSClient)
+		ctx.kms = mockKMS
+
+		body, metadata := putEncrypted(t, mockKMS, "pii.json", map[string]interface{}{"document": "123.456.789-00"})
+		assert.NotContains(t, string(body), "123.456.789-00")
+		assert.Equal(t, "AES-256-GCM", aws.StringValue(metadata["Cec-Cipher"]))
+
+		mockKMS.On("Decrypt", mock.MatchedBy(func(input *kms.DecryptInput) bool {
+			return string(input.CiphertextBlob) == "encrypted-data-key" &&
+				aws.StringValue(input.EncryptionContext["app"]) == "checkout"
+		})).Return(&kms.DecryptOutput{Plaintext: dataKey}, nil)
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body:     io.NopCloser(bytes.NewReader(body)),
+			Metadata: metadata,
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "pii.json")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"document": "123.456.789-00"}, result)
+	})
+
+	t.Run("Reject tampered content", func(t *testing.T) {
+		loadDefaultVariables()
+		mockKMS := new(mockKMSClient)
+		ctx.kms = mockKMS
+
+		body, metadata := putEncrypted(t, mockKMS, "pii.txt", "secret")
+		body[len(body)-1] ^= 0xFF
+
+		mockKMS.On("Decrypt", mock.Anything).Return(&kms.DecryptOutput{Plaintext: dataKey}, nil)
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body:     io.NopCloser(bytes.NewReader(body)),
+			Metadata: metadata,
+		}, nil)
+
+		_, err := ctx.GetValue("test-bucket", "pii.txt")
+
+		assert.ErrorContains(t, err, "error when decrypting S3 object")
+	})
+
+	t.Run("Reject content copied to another key", func(t *testing.T) {
+		loadDefaultVariables()
+		mockKMS := new(mockKMSClient)
+		ctx.kms = mockKMS
+
+		body, metadata := putEncrypted(t, mockKMS, "pii.txt", "secret")
+
+		mockKMS.On("Decrypt", mock.Anything).Return(&kms.DecryptOutput{Plaintext: dataKey}, nil)
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body:     io.NopCloser(bytes.NewReader(body)),
+			Metadata: metadata,
+		}, nil)
+
+		_, err := ctx.GetValue("test-bucket", "public.txt")
+
+		assert.ErrorContains(t, err, "error when decrypting S3 object")
+	})
+
+	t.Run("Toggle encryption while writing", func(t *testing.T) {
+		loadDefaultVariables()
+		mockKMS := new(mockKMSClient)
+		ctx.kms = mockKMS
+
+		mockKMS.On("GenerateDataKey", mock.Anything).Return(&kms.GenerateDataKeyOutput{
+			KeyId:          aws.String("alias/config"),
+			Plaintext:      dataKey,
+			CiphertextBlob: []byte("encrypted-data-key"),
+		}, nil)
+		mockS3.On("PutObject", mock.Anything).Return(&s3.PutObjectOutput{}, nil)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				assert.NoError(t, ctx.EnableEncryption("alias/config", nil))
+				ctx.DisableEncryption()
+			}
+		}()
+		for i := 0; i < 50; i++ {
+			_, err := ctx.PutValue("test-bucket", "notes.txt", "plain")
+			assert.NoError(t, err)
+		}
+		wg.Wait()
+	})
+
+	t.Run("Multipart uploads are rejected while encryption is enabled", func(t *testing.T) {
+		loadDefaultVariables()
+		ctx.kms = new(mockKMSClient)
+
+		assert.NoError(t, ctx.EnableEncryption("alias/config", nil))
+		_, err := ctx.Upload("test-bucket", "large.csv", bytes.NewReader([]byte("id\n1\n")))
+
+		assert.Error(t, err)
+		mockUploader.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Decrypt streamed reads", func(t *testing.T) {
+		loadDefaultVariables()
+		mockKMS := new(mockKMSClient)
+		ctx.kms = mockKMS
+
+		body, metadata := putEncrypted(t, mockKMS, "pii.txt", "secret")
+
+		mockKMS.On("Decrypt", mock.Anything).Return(&kms.DecryptOutput{Plaintext: dataKey}, nil)
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body:     io.NopCloser(bytes.NewReader(body)),
+			Metadata: metadata,
+		}, nil)
+
+		reader, err := ctx.GetReader("test-bucket", "pii.txt")
+		assert.NoError(t, err)
+		content, _ := io.ReadAll(reader)
+
+		assert.Equal(t, "secret", string(content))
+	})
+
+	t.Run("Refuse range reads and select of encrypted objects", func(t *testing.T) {
+		loadDefaultVariables()
+		mockKMS := new(mockKMSClient)
+		ctx.kms = mockKMS
+
+		body, metadata := putEncrypted(t, mockKMS, "pii.txt", "secret")
+
+		_, err := ctx.GetRange("test-bucket", "pii.txt", 0, 10)
+		assert.Error(t, err)
+		_, err = ctx.Select("test-bucket", "pii.txt", "SELECT * FROM s3object", FormatCSV)
+		assert.Error(t, err)
+
+		// Sem a criptografia habilitada, o envelope é identificado pelos metadados do objeto
+		ctx.DisableEncryption()
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body:     io.NopCloser(bytes.NewReader(body)),
+			Metadata: metadata,
+		}, nil)
+		mockS3.On("HeadObject", mock.Anything).Return(&s3.HeadObjectOutput{Metadata: metadata}, nil)
+
+		_, err = ctx.GetRange("test-bucket", "pii.txt", 0, 10)
+		assert.ErrorContains(t, err, "encrypted on the client side")
+		_, err = ctx.Select("test-bucket", "pii.txt", "SELECT * FROM s3object", FormatCSV)
+		assert.ErrorContains(t, err, "encrypted on the client side")
+		mockS3.AssertNotCalled(t, "SelectObjectContent", mock.Anything)
+	})
+
+	t.Run("Plain objects are not decrypted", func(t *testing.T) {
+		loadDefaultVariables()
+		mockKMS := new(mockKMSClient)
+		ctx.kms = mockKMS
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader([]byte("plain"))),
+		}, nil)
+
+		result, err := ctx.GetValue("test-bucket", "notes.txt")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "plain", result)
+		mockKMS.AssertNotCalled(t, "Decrypt", mock.Anything)
+	})
+}
@@ -0,0 +1,103 @@
+package s3
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format identifica o formato usado para decodificar o conteúdo de um objeto S3
+type Format string
+
+const (
+	FormatAuto Format = ""
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatCSV  Format = "csv"
+	FormatText Format = "text"
+
+	FormatTOML       Format = "toml"
+	FormatDotEnv     Format = "dotenv"
+	FormatProperties Format = "properties"
+	FormatXML        Format = "xml"
+	FormatNDJSON     Format = "ndjson"
+
+	// FormatParquet é suportado apenas como formato de entrada do S3 Select
+	FormatParquet Format = "parquet"
+)
+
+var (
+	utf8BOM     = []byte{0xEF, 0xBB, 0xBF}
+	yamlKeyLine = regexp.MustCompile(`^[A-Za-z0-9_.-]+:(\s|$)`)
+)
+
+// WithFormat ignora a detecção automática e decodifica o objeto no formato informado
+func WithFormat(format Format) GetOption {
+	return func(o *GetOptions) {
+		o.Format = format
+	}
+}
+
+// detectFormat determina o formato do objeto pela extensão, pelo Content-Type e, por fim, pelo conteúdo
+func detectFormat(keyName, contentType string, body []byte) Format {
+	if format := formatFromExtension(keyName); format != FormatAuto {
+		return format
+	}
+	if format := formatFromContentType(contentType); format != FormatAuto {
+		return format
+	}
+	return sniffFormat(body)
+}
+
+// sniffFormat inspeciona o conteúdo para identificar documentos JSON, YAML ou CSV
+func sniffFormat(body []byte) Format {
+	content := bytes.TrimSpace(bytes.TrimPrefix(body, utf8BOM))
+	if len(content) == 0 {
+		return FormatText
+	}
+
+	if (content[0] == '{' || content[0] == '[') && json.Valid(content) {
+		return FormatJSON
+	}
+
+	if looksLikeYAML(content) {
+		return FormatYAML
+	}
+
+	if looksLikeCSV(content) {
+		return FormatCSV
+	}
+
+	return FormatText
+}
+
+func looksLikeYAML(content []byte) bool {
+	firstLine := ""
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		firstLine = line
+		break
+	}
+	if firstLine != "---" && !yamlKeyLine.MatchString(firstLine) {
+		return false
+	}
+
+	var document map[string]interface{}
+	return yaml.Unmarshal(content, &document) == nil && len(document) > 0
+}
+
+func looksLikeCSV(content []byte) bool {
+	if !bytes.Contains(content, []byte("\n")) {
+		return false
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	return err == nil && len(records) > 1 && len(records[0]) > 1
+}
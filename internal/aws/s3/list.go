@@ -0,0 +1,164 @@
+package s3
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	s3bucket "github.com/aws/aws-sdk-go/service/s3"
+)
+
+// defaultLoadWorkers é a quantidade padrão de downloads simultâneos em LoadPrefix
+const defaultLoadWorkers = 8
+
+// ObjectSummary descreve um objeto retornado pela listagem do bucket
+type ObjectSummary struct {
+	Key          string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+	StorageClass string
+}
+
+// LoadError reúne as falhas individuais de LoadPrefix, indexadas pela chave do objeto
+type LoadError struct {
+	Errors map[string]error
+}
+
+func (e *LoadError) Error() string {
+	keys := make([]string, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	messages := make([]string, 0, len(keys))
+	for _, key := range keys {
+		messages = append(messages, fmt.Sprintf("%s: %v", key, e.Errors[key]))
+	}
+	return fmt.Sprintf("error when loading %d S3 objects: %s", len(keys), strings.Join(messages, "; "))
+}
+
+// WithWorkers define quantos objetos são baixados simultaneamente em LoadPrefix
+func WithWorkers(workers int) GetOption {
+	return func(o *GetOptions) {
+		o.Workers = workers
+	}
+}
+
+// ListPages percorre as páginas da listagem do prefixo; a função retorna false para interromper
+func (ctx *S3CloudContext) ListPages(bucketName, prefix string, fn func(page []ObjectSummary, lastPage bool) bool) error {
+	input := &s3bucket.ListObjectsV2Input{
+		Bucket: aws.String(bucketName),
+		Prefix: aws.String(prefix),
+	}
+
+	for {
+		result, err := ctx.svc.ListObjectsV2(input)
+		if err != nil {
+			return fmt.Errorf("error when listing S3 objects: %w", err)
+		}
+
+		page := make([]ObjectSummary, 0, len(result.Contents))
+		for _, object := range result.Contents {
+			page = append(page, ObjectSummary{
+				Key:          aws.StringValue(object.Key),
+				Size:         aws.Int64Value(object.Size),
+				ETag:         aws.StringValue(object.ETag),
+				LastModified: aws.TimeValue(object.LastModified),
+				StorageClass: aws.StringValue(object.StorageClass),
+			})
+		}
+
+		lastPage := !aws.BoolValue(result.IsTruncated) || result.NextContinuationToken == nil
+		if !fn(page, lastPage) || lastPage {
+			return nil
+		}
+		input.ContinuationToken = result.NextContinuationToken
+	}
+}
+
+// List retorna todos os objetos do prefixo, percorrendo todas as páginas
+func (ctx *S3CloudContext) List(bucketName, prefix string) ([]ObjectSummary, error) {
+	objects := make([]ObjectSummary, 0)
+	err := ctx.ListPages(bucketName, prefix, func(page []ObjectSummary, lastPage bool) bool {
+		objects = append(objects, page...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// LoadPrefix baixa e decodifica simultaneamente todos os objetos do prefixo; os objetos com
+// falha são informados em um *LoadError e os demais continuam presentes no resultado. As opções são
+// aplicadas a todos os objetos, por isso WithVersionID, que identifica a versão de um único objeto,
+// não é aceito
+func (ctx *S3CloudContext) LoadPrefix(bucketName, prefix string, opts ...GetOption) (map[string]interface{}, error) {
+	options := newGetOptions(opts)
+	if options.VersionID != "" {
+		return nil, errors.New("a version id cannot be applied to every object of a prefix")
+	}
+	workers := options.Workers
+	if workers <= 0 {
+		workers = defaultLoadWorkers
+	}
+
+	objects, err := ctx.List(bucketName, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mutex  sync.Mutex
+		wg     sync.WaitGroup
+		keys   = make(chan string)
+		values = make(map[string]interface{}, len(objects))
+		errs   = make(map[string]error)
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keys {
+				value, err := ctx.GetValue(bucketName, key, opts...)
+
+				mutex.Lock()
+				if err != nil {
+					errs[key] = err
+				} else {
+					values[key] = value
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	// As assinaturas irmãs são verificadas junto com o objeto e não são carregadas como valores
+	signature := ctx.signatureSettings()
+	skipSignatures := signature != nil && signature.location == SignatureSibling
+
+	for _, object := range objects {
+		// Chaves terminadas em "/" são marcadores de pasta criados pelo console
+		if strings.HasSuffix(object.Key, "/") {
+			continue
+		}
+		if skipSignatures && isSignatureKey(object.Key) {
+			continue
+		}
+		keys <- object.Key
+	}
+	close(keys)
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return values, &LoadError{Errors: errs}
+	}
+	return values, nil
+}
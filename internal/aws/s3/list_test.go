@@ -0,0 +1,121 @@
+package s3
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockTenantPages() {
+	mockS3.On("ListObjectsV2", mock.MatchedBy(func(input *s3.ListObjectsV2Input) bool {
+		return input.ContinuationToken == nil && aws.StringValue(input.Prefix) == "tenants/"
+	})).Return(&s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			{Key: aws.String("tenants/"), Size: aws.Int64(0)},
+			{Key: aws.String("tenants/alpha.yaml"), Size: aws.Int64(12)},
+		},
+		IsTruncated:           aws.Bool(true),
+		NextContinuationToken: aws.String("page-2"),
+	}, nil)
+
+	mockS3.On("ListObjectsV2", mock.MatchedBy(func(input *s3.ListObjectsV2Input) bool {
+		return aws.StringValue(input.ContinuationToken) == "page-2"
+	})).Return(&s3.ListObjectsV2Output{
+		Contents: []*s3.Object{
+			{Key: aws.String("tenants/beta.yaml"), Size: aws.Int64(11)},
+		},
+		IsTruncated: aws.Bool(false),
+	}, nil)
+}
+
+func mockObject(keyName, content string) {
+	mockS3.On("GetObject", mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+		return aws.StringValue(input.Key) == keyName
+	})).Return(&s3.GetObjectOutput{
+		Body: io.NopCloser(strings.NewReader(content)),
+	}, nil)
+}
+
+func TestS3CloudContext_List(t *testing.T) {
+	t.Run("List all pages", func(t *testing.T) {
+		loadDefaultVariables()
+		mockTenantPages()
+
+		objects, err := ctx.List("test-bucket", "tenants/")
+
+		assert.NoError(t, err)
+		assert.Len(t, objects, 3)
+		assert.Equal(t, "tenants/beta.yaml", objects[2].Key)
+		assert.Equal(t, int64(11), objects[2].Size)
+	})
+
+	t.Run("Stop listing early", func(t *testing.T) {
+		loadDefaultVariables()
+		mockTenantPages()
+
+		pages := 0
+		err := ctx.ListPages("test-bucket", "tenants/", func(page []ObjectSummary, lastPage bool) bool {
+			pages++
+			return false
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, pages)
+	})
+
+	t.Run("List error", func(t *testing.T) {
+		loadDefaultVariables()
+		mockS3.On("ListObjectsV2", mock.Anything).Return((*s3.ListObjectsV2Output)(nil), errors.New("access denied"))
+
+		_, err := ctx.List("test-bucket", "tenants/")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestS3CloudContext_LoadPrefix(t *testing.T) {
+	t.Run("Load every object", func(t *testing.T) {
+		loadDefaultVariables()
+		mockTenantPages()
+		mockObject("tenants/alpha.yaml", "plan: gold")
+		mockObject("tenants/beta.yaml", "plan: silver")
+
+		values, err := ctx.LoadPrefix("test-bucket", "tenants/", WithWorkers(2))
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"tenants/alpha.yaml": map[string]interface{}{"plan": "gold"},
+			"tenants/beta.yaml":  map[string]interface{}{"plan": "silver"},
+		}, values)
+	})
+
+	t.Run("Report per-key errors", func(t *testing.T) {
+		loadDefaultVariables()
+		mockTenantPages()
+		mockObject("tenants/alpha.yaml", "plan: gold")
+		mockObject("tenants/beta.yaml", "plan: [invalid")
+
+		values, err := ctx.LoadPrefix("test-bucket", "tenants/", WithQuery("plan"))
+
+		var loadErr *LoadError
+		assert.True(t, errors.As(err, &loadErr))
+		assert.Contains(t, loadErr.Errors, "tenants/beta.yaml")
+		assert.Equal(t, map[string]interface{}{"tenants/alpha.yaml": "gold"}, values)
+	})
+
+	t.Run("Reject a version id", func(t *testing.T) {
+		loadDefaultVariables()
+
+		values, err := ctx.LoadPrefix("test-bucket", "tenants/", WithVersionID("v1"))
+
+		assert.Error(t, err)
+		assert.Nil(t, values)
+		mockS3.AssertNotCalled(t, "ListObjectsV2", mock.Anything)
+	})
+}
@@ -0,0 +1,162 @@
+package s3
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	s3bucket "github.com/aws/aws-sdk-go/service/s3"
+)
+
+// Object contém o conteúdo decodificado de um objeto S3 junto com os dados da revisão lida
+type Object struct {
+	Value        interface{}
+	Key          string
+	VersionID    string
+	ETag         string
+	ContentType  string
+	Size         int64
+	LastModified time.Time
+	Metadata     map[string]string
+	Tags         map[string]string
+}
+
+// ObjectVersion descreve uma versão de um objeto em um bucket versionado
+type ObjectVersion struct {
+	Key            string
+	VersionID      string
+	ETag           string
+	Size           int64
+	LastModified   time.Time
+	IsLatest       bool
+	IsDeleteMarker bool
+}
+
+// WithVersionID lê a versão informada do objeto em vez da mais recente
+func WithVersionID(versionID string) GetOption {
+	return func(o *GetOptions) {
+		o.VersionID = versionID
+	}
+}
+
+// WithTagging inclui as tags do objeto no resultado de GetObject, o que exige uma chamada adicional
+func WithTagging() GetOption {
+	return func(o *GetOptions) {
+		o.Tagging = true
+	}
+}
+
+// GetObject obtém e decodifica o objeto, retornando também metadados, tags e identificadores da revisão
+func (ctx *S3CloudContext) GetObject(bucketName, keyName string, opts ...GetOption) (*Object, error) {
+	options := newGetOptions(opts)
+
+	input := &s3bucket.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(keyName),
+	}
+	if options.VersionID != "" {
+		input.VersionId = aws.String(options.VersionID)
+	}
+
+	result, err := ctx.svc.GetObject(input)
+	if err != nil {
+		return nil, fmt.Errorf("error when obtaining S3 object: %w", err)
+	}
+
+	content, bodyBytes, err := ctx.readContent(bucketName, keyName, options.VersionID, result)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := decodeObject(content, bodyBytes, options)
+	if err != nil {
+		return nil, err
+	}
+
+	object := &Object{
+		Value:        value,
+		Key:          keyName,
+		VersionID:    aws.StringValue(result.VersionId),
+		ETag:         aws.StringValue(result.ETag),
+		ContentType:  aws.StringValue(result.ContentType),
+		Size:         aws.Int64Value(result.ContentLength),
+		LastModified: aws.TimeValue(result.LastModified),
+		Metadata:     aws.StringValueMap(result.Metadata),
+	}
+
+	if options.Tagging {
+		tagInput := &s3bucket.GetObjectTaggingInput{
+			Bucket: aws.String(bucketName),
+			Key:    aws.String(keyName),
+		}
+		if object.VersionID != "" {
+			tagInput.VersionId = aws.String(object.VersionID)
+		}
+
+		tagging, err := ctx.svc.GetObjectTagging(tagInput)
+		if err != nil {
+			return nil, fmt.Errorf("error when obtaining S3 object tags: %w", err)
+		}
+
+		object.Tags = make(map[string]string, len(tagging.TagSet))
+		for _, tag := range tagging.TagSet {
+			object.Tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+	}
+	return object, nil
+}
+
+// ListVersions retorna todas as versões e marcadores de exclusão do objeto, da mais recente para a mais antiga
+func (ctx *S3CloudContext) ListVersions(bucketName, keyName string) ([]ObjectVersion, error) {
+	input := &s3bucket.ListObjectVersionsInput{
+		Bucket: aws.String(bucketName),
+		Prefix: aws.String(keyName),
+	}
+
+	versions := make([]ObjectVersion, 0)
+	for {
+		result, err := ctx.svc.ListObjectVersions(input)
+		if err != nil {
+			return nil, fmt.Errorf("error when listing S3 object versions: %w", err)
+		}
+
+		// O filtro por prefixo também retorna chaves que apenas começam com o nome do objeto
+		for _, version := range result.Versions {
+			if aws.StringValue(version.Key) != keyName {
+				continue
+			}
+			versions = append(versions, ObjectVersion{
+				Key:          keyName,
+				VersionID:    aws.StringValue(version.VersionId),
+				ETag:         aws.StringValue(version.ETag),
+				Size:         aws.Int64Value(version.Size),
+				LastModified: aws.TimeValue(version.LastModified),
+				IsLatest:     aws.BoolValue(version.IsLatest),
+			})
+		}
+		for _, marker := range result.DeleteMarkers {
+			if aws.StringValue(marker.Key) != keyName {
+				continue
+			}
+			versions = append(versions, ObjectVersion{
+				Key:            keyName,
+				VersionID:      aws.StringValue(marker.VersionId),
+				LastModified:   aws.TimeValue(marker.LastModified),
+				IsLatest:       aws.BoolValue(marker.IsLatest),
+				IsDeleteMarker: true,
+			})
+		}
+
+		if !aws.BoolValue(result.IsTruncated) {
+			break
+		}
+		input.KeyMarker = result.NextKeyMarker
+		input.VersionIdMarker = result.NextVersionIdMarker
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].LastModified.After(versions[j].LastModified)
+	})
+	return versions, nil
+}
@@ -0,0 +1,106 @@
+package s3
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestS3CloudContext_GetObject(t *testing.T) {
+	lastModified := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Get specific version with metadata and tags", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+			return aws.StringValue(input.VersionId) == "v1"
+		})).Return(&s3.GetObjectOutput{
+			Body:          io.NopCloser(strings.NewReader(`{"flag": true}`)),
+			VersionId:     aws.String("v1"),
+			ETag:          aws.String(`"etag-v1"`),
+			ContentType:   aws.String("application/json"),
+			ContentLength: aws.Int64(14),
+			LastModified:  aws.Time(lastModified),
+			Metadata:      map[string]*string{"Author": aws.String("pipeline")},
+		}, nil)
+		mockS3.On("GetObjectTagging", mock.MatchedBy(func(input *s3.GetObjectTaggingInput) bool {
+			return aws.StringValue(input.VersionId) == "v1"
+		})).Return(&s3.GetObjectTaggingOutput{
+			TagSet: []*s3.Tag{{Key: aws.String("env"), Value: aws.String("prod")}},
+		}, nil)
+
+		object, err := ctx.GetObject("test-bucket", "flags.json", WithVersionID("v1"), WithTagging())
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"flag": true}, object.Value)
+		assert.Equal(t, "v1", object.VersionID)
+		assert.Equal(t, `"etag-v1"`, object.ETag)
+		assert.Equal(t, "application/json", object.ContentType)
+		assert.Equal(t, int64(14), object.Size)
+		assert.Equal(t, lastModified, object.LastModified)
+		assert.Equal(t, map[string]string{"Author": "pipeline"}, object.Metadata)
+		assert.Equal(t, map[string]string{"env": "prod"}, object.Tags)
+	})
+
+	t.Run("Tags are not requested by default", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(strings.NewReader("text")),
+		}, nil)
+
+		object, err := ctx.GetObject("test-bucket", "notes.txt")
+
+		assert.NoError(t, err)
+		assert.Nil(t, object.Tags)
+		mockS3.AssertNotCalled(t, "GetObjectTagging", mock.Anything)
+	})
+}
+
+func TestS3CloudContext_ListVersions(t *testing.T) {
+	t.Run("List versions of a single key", func(t *testing.T) {
+		loadDefaultVariables()
+
+		older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		newer := older.Add(24 * time.Hour)
+
+		mockS3.On("ListObjectVersions", mock.MatchedBy(func(input *s3.ListObjectVersionsInput) bool {
+			return input.KeyMarker == nil
+		})).Return(&s3.ListObjectVersionsOutput{
+			Versions: []*s3.ObjectVersion{
+				{Key: aws.String("flags.json"), VersionId: aws.String("v1"), LastModified: aws.Time(older)},
+				{Key: aws.String("flags.json.bak"), VersionId: aws.String("b1"), LastModified: aws.Time(older)},
+			},
+			IsTruncated:         aws.Bool(true),
+			NextKeyMarker:       aws.String("flags.json"),
+			NextVersionIdMarker: aws.String("v1"),
+		}, nil)
+		mockS3.On("ListObjectVersions", mock.MatchedBy(func(input *s3.ListObjectVersionsInput) bool {
+			return aws.StringValue(input.VersionIdMarker) == "v1"
+		})).Return(&s3.ListObjectVersionsOutput{
+			Versions: []*s3.ObjectVersion{
+				{Key: aws.String("flags.json"), VersionId: aws.String("v2"), LastModified: aws.Time(newer), IsLatest: aws.Bool(true)},
+			},
+			DeleteMarkers: []*s3.DeleteMarkerEntry{
+				{Key: aws.String("flags.json"), VersionId: aws.String("d1"), LastModified: aws.Time(older.Add(time.Hour))},
+			},
+			IsTruncated: aws.Bool(false),
+		}, nil)
+
+		versions, err := ctx.ListVersions("test-bucket", "flags.json")
+
+		assert.NoError(t, err)
+		assert.Len(t, versions, 3)
+		assert.Equal(t, "v2", versions[0].VersionID)
+		assert.True(t, versions[0].IsLatest)
+		assert.Equal(t, "d1", versions[1].VersionID)
+		assert.True(t, versions[1].IsDeleteMarker)
+		assert.Equal(t, "v1", versions[2].VersionID)
+	})
+}
@@ -0,0 +1,42 @@
+package s3
+
+// GetOption configura uma leitura individual de objeto S3
+type GetOption func(*GetOptions)
+
+// GetOptions reúne as configurações aplicadas em uma leitura de objeto S3
+type GetOptions struct {
+	// Query é uma expressão JMESPath avaliada sobre o documento decodificado
+	Query string
+
+	// Format força o formato de decodificação, ignorando a detecção automática
+	Format Format
+
+	// CSV configura a interpretação de objetos CSV
+	CSV *CSVOptions
+
+	// Workers limita os downloads simultâneos das leituras em lote
+	Workers int
+
+	// VersionID seleciona uma versão específica do objeto
+	VersionID string
+
+	// Tagging inclui as tags do objeto no resultado de GetObject
+	Tagging bool
+}
+
+// WithQuery aplica uma expressão JMESPath sobre o conteúdo decodificado e retorna apenas o trecho selecionado
+func WithQuery(expression string) GetOption {
+	return func(o *GetOptions) {
+		o.Query = expression
+	}
+}
+
+func newGetOptions(opts []GetOption) *GetOptions {
+	options := &GetOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
@@ -0,0 +1,256 @@
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	s3bucket "github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+	// maxPresignExpiry é o maior prazo aceito pelo S3 para assinaturas SigV4
+	maxPresignExpiry = 7 * 24 * time.Hour
+
+	// maxPostContentLength é o maior objeto aceito pelo S3 em um upload via POST
+	maxPostContentLength = 5 << 30
+)
+
+// PresignedRequest contém a URL assinada e os cabeçalhos que o cliente deve enviar junto
+type PresignedRequest struct {
+	URL       string
+	Method    string
+	Header    http.Header
+	ExpiresAt time.Time
+}
+
+// PostPolicy descreve as restrições de um upload via formulário HTML (POST)
+type PostPolicy struct {
+	// Key é a chave exata do objeto; ignorada quando KeyPrefix é informado
+	Key string
+	// KeyPrefix permite qualquer chave que comece com o prefixo
+	KeyPrefix string
+	// ContentType exige o Content-Type informado
+	ContentType string
+	// MinContentLength e MaxContentLength limitam o tamanho do arquivo enviado; sem MaxContentLength,
+	// o mínimo é aplicado com o limite de 5 GB do S3 para uploads via POST
+	MinContentLength int64
+	MaxContentLength int64
+	// Metadata exige os metadados de usuário informados
+	Metadata map[string]string
+	// Expiry define a validade da política
+	Expiry time.Duration
+}
+
+// PresignedPost contém a URL e os campos de formulário que devem acompanhar o arquivo
+type PresignedPost struct {
+	URL       string
+	Fields    map[string]string
+	ExpiresAt time.Time
+}
+
+// WithContentLength exige o tamanho informado em uploads com URL assinada
+func WithContentLength(contentLength int64) PutOption {
+	return func(o *PutOptions) {
+		o.ContentLength = contentLength
+	}
+}
+
+// PresignGet gera uma URL temporária para download do objeto
+func (ctx *S3CloudContext) PresignGet(bucketName, keyName string, expiry time.Duration) (*PresignedRequest, error) {
+	if err := validateExpiry(expiry); err != nil {
+		return nil, err
+	}
+
+	req, _ := ctx.svc.GetObjectRequest(&s3bucket.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(keyName),
+	})
+
+	url, header, err := req.PresignRequest(expiry)
+	if err != nil {
+		return nil, fmt.Errorf("error when presigning S3 download: %w", err)
+	}
+
+	return &PresignedRequest{
+		URL:       url,
+		Method:    http.MethodGet,
+		Header:    canonicalHeader(header),
+		ExpiresAt: time.Now().Add(expiry),
+	}, nil
+}
+
+// PresignPut gera uma URL temporária para upload do objeto; Content-Type, tamanho, criptografia,
+// metadados e tags informados passam a fazer parte da assinatura e devem ser enviados pelo cliente
+func (ctx *S3CloudContext) PresignPut(bucketName, keyName string, expiry time.Duration, opts ...PutOption) (*PresignedRequest, error) {
+	if err := validateExpiry(expiry); err != nil {
+		return nil, err
+	}
+	options := newPutOptions(opts)
+
+	input := &s3bucket.PutObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(keyName),
+	}
+	if options.ContentType != "" {
+		input.ContentType = aws.String(options.ContentType)
+	}
+	if options.ContentLength > 0 {
+		input.ContentLength = aws.Int64(options.ContentLength)
+	}
+	applyPutOptions(input, options)
+
+	req, _ := ctx.svc.PutObjectRequest(input)
+
+	url, header, err := req.PresignRequest(expiry)
+	if err != nil {
+		return nil, fmt.Errorf("error when presigning S3 upload: %w", err)
+	}
+
+	return &PresignedRequest{
+		URL:       url,
+		Method:    http.MethodPut,
+		Header:    canonicalHeader(header),
+		ExpiresAt: time.Now().Add(expiry),
+	}, nil
+}
+
+// PresignPost gera a política assinada (SigV4) para uploads diretos do navegador via formulário
+func (ctx *S3CloudContext) PresignPost(bucketName string, policy PostPolicy) (*PresignedPost, error) {
+	if err := validateExpiry(policy.Expiry); err != nil {
+		return nil, err
+	}
+	if policy.Key == "" && policy.KeyPrefix == "" {
+		return nil, errors.New("the post policy requires a key or a key prefix")
+	}
+	if policy.MinContentLength < 0 || policy.MaxContentLength < 0 {
+		return nil, errors.New("the post policy content length limits cannot be negative")
+	}
+	if policy.MaxContentLength > 0 && policy.MinContentLength > policy.MaxContentLength {
+		return nil, errors.New("the post policy minimum content length is greater than the maximum")
+	}
+	if ctx.config == nil || ctx.config.Credentials == nil {
+		return nil, errors.New("the s3 context has no credentials to sign the post policy")
+	}
+
+	creds, err := ctx.config.Credentials.Get()
+	if err != nil {
+		return nil, fmt.Errorf("error when obtaining AWS credentials: %w", err)
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(policy.Expiry)
+	region := aws.StringValue(ctx.config.Region)
+	date := now.Format("20060102")
+	credential := fmt.Sprintf("%s/%s/%s/s3/aws4_request", creds.AccessKeyID, date, region)
+
+	fields := map[string]string{
+		"x-amz-algorithm":  "AWS4-HMAC-SHA256",
+		"x-amz-credential": credential,
+		"x-amz-date":       now.Format("20060102T150405Z"),
+	}
+	conditions := []interface{}{
+		map[string]string{"bucket": bucketName},
+	}
+
+	if policy.KeyPrefix != "" {
+		fields["key"] = policy.KeyPrefix + "${filename}"
+		conditions = append(conditions, []interface{}{"starts-with", "$key", policy.KeyPrefix})
+	} else {
+		fields["key"] = policy.Key
+	}
+	if policy.ContentType != "" {
+		fields["Content-Type"] = policy.ContentType
+	}
+	if policy.MinContentLength > 0 || policy.MaxContentLength > 0 {
+		maxContentLength := policy.MaxContentLength
+		if maxContentLength == 0 {
+			maxContentLength = maxPostContentLength
+		}
+		conditions = append(conditions, []interface{}{"content-length-range", policy.MinContentLength, maxContentLength})
+	}
+	for key, value := range policy.Metadata {
+		fields["x-amz-meta-"+strings.ToLower(key)] = value
+	}
+	if creds.SessionToken != "" {
+		fields["x-amz-security-token"] = creds.SessionToken
+	}
+	for name, value := range fields {
+		if name == "key" && policy.KeyPrefix != "" {
+			continue
+		}
+		conditions = append(conditions, map[string]string{name: value})
+	}
+
+	document, err := json.Marshal(map[string]interface{}{
+		"expiration": expiresAt.Format("2006-01-02T15:04:05.000Z"),
+		"conditions": conditions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when encoding post policy: %w", err)
+	}
+
+	encodedPolicy := base64.StdEncoding.EncodeToString(document)
+	signingKey := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	fields["policy"] = encodedPolicy
+	fields["x-amz-signature"] = hex.EncodeToString(hmacSHA256(signingKey, encodedPolicy))
+
+	return &PresignedPost{
+		URL:       ctx.bucketURL(bucketName),
+		Fields:    fields,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// bucketURL monta o endereço do bucket respeitando o endpoint customizado da sessão
+func (ctx *S3CloudContext) bucketURL(bucketName string) string {
+	endpoint := aws.StringValue(ctx.config.Endpoint)
+	if endpoint != "" {
+		if !strings.Contains(endpoint, "://") {
+			endpoint = "https://" + endpoint
+		}
+		return strings.TrimSuffix(endpoint, "/") + "/" + bucketName
+	}
+
+	region := aws.StringValue(ctx.config.Region)
+	if aws.BoolValue(ctx.config.S3ForcePathStyle) {
+		return fmt.Sprintf("https://s3.%s.amazonaws.com/%s", region, bucketName)
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com", bucketName, region)
+}
+
+// canonicalHeader normaliza os nomes dos cabeçalhos assinados, que o SDK retorna em minúsculas
+func canonicalHeader(header http.Header) http.Header {
+	canonical := make(http.Header, len(header))
+	for name, values := range header {
+		for _, value := range values {
+			canonical.Add(name, value)
+		}
+	}
+	return canonical
+}
+
+func validateExpiry(expiry time.Duration) error {
+	if expiry <= 0 || expiry > maxPresignExpiry {
+		return fmt.Errorf("invalid presign expiry: %s, it must be between 1s and %s", expiry, maxPresignExpiry)
+	}
+	return nil
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,156 @@
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/stretchr/testify/assert"
+)
+
+// loadPresignContext cria um contexto com credenciais estáticas, já que a assinatura não acessa a rede
+func loadPresignContext(t *testing.T, endpoint string) *S3CloudContext {
+	config := &aws.Config{
+		Region:      aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials("AKIDEXAMPLE", "secret", "token"),
+	}
+	if endpoint != "" {
+		config.Endpoint = aws.String(endpoint)
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	sess, err := session.NewSession(config)
+	assert.NoError(t, err)
+	return NewS3Context(sess)
+}
+
+func TestS3CloudContext_Presign(t *testing.T) {
+	t.Run("Presign download", func(t *testing.T) {
+		presignCtx := loadPresignContext(t, "")
+
+		result, err := presignCtx.PresignGet("test-bucket", "reports/2024.csv", 15*time.Minute)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "GET", result.Method)
+
+		parsed, err := url.Parse(result.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "test-bucket.s3.amazonaws.com", parsed.Host)
+		assert.Equal(t, "/reports/2024.csv", parsed.Path)
+		assert.Equal(t, "900", parsed.Query().Get("X-Amz-Expires"))
+		assert.NotEmpty(t, parsed.Query().Get("X-Amz-Signature"))
+	})
+
+	t.Run("Presign upload with content constraints", func(t *testing.T) {
+		presignCtx := loadPresignContext(t, "http://localhost:4566")
+
+		result, err := presignCtx.PresignPut("test-bucket", "uploads/file.json", time.Hour,
+			WithContentType("application/json"),
+			WithContentLength(1024),
+			WithSSEKMS("alias/uploads"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "PUT", result.Method)
+		assert.Equal(t, "application/json", result.Header.Get("Content-Type"))
+		assert.Equal(t, "1024", result.Header.Get("Content-Length"))
+		assert.Equal(t, "alias/uploads", result.Header.Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"))
+
+		parsed, err := url.Parse(result.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "localhost:4566", parsed.Host)
+		assert.Equal(t, "/test-bucket/uploads/file.json", parsed.Path)
+		assert.Contains(t, parsed.Query().Get("X-Amz-SignedHeaders"), "content-type")
+	})
+
+	t.Run("Reject invalid expiry", func(t *testing.T) {
+		presignCtx := loadPresignContext(t, "")
+
+		_, err := presignCtx.PresignGet("test-bucket", "file.txt", 8*24*time.Hour)
+		assert.Error(t, err)
+
+		_, err = presignCtx.PresignPut("test-bucket", "file.txt", 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestS3CloudContext_PresignPost(t *testing.T) {
+	t.Run("Build signed post policy", func(t *testing.T) {
+		presignCtx := loadPresignContext(t, "")
+
+		result, err := presignCtx.PresignPost("test-bucket", PostPolicy{
+			KeyPrefix:        "uploads/tenant-a/",
+			ContentType:      "image/png",
+			MaxContentLength: 5 << 20,
+			Metadata:         map[string]string{"Tenant": "a"},
+			Expiry:           10 * time.Minute,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "https://test-bucket.s3.us-east-1.amazonaws.com", result.URL)
+		assert.Equal(t, "uploads/tenant-a/${filename}", result.Fields["key"])
+		assert.Equal(t, "image/png", result.Fields["Content-Type"])
+		assert.Equal(t, "a", result.Fields["x-amz-meta-tenant"])
+		assert.Equal(t, "token", result.Fields["x-amz-security-token"])
+		assert.Contains(t, result.Fields["x-amz-credential"], "AKIDEXAMPLE/")
+
+		document, err := base64.StdEncoding.DecodeString(result.Fields["policy"])
+		assert.NoError(t, err)
+
+		var policy struct {
+			Expiration string        `json:"expiration"`
+			Conditions []interface{} `json:"conditions"`
+		}
+		assert.NoError(t, json.Unmarshal(document, &policy))
+		assert.Contains(t, policy.Conditions, []interface{}{"starts-with", "$key", "uploads/tenant-a/"})
+		assert.Contains(t, policy.Conditions, []interface{}{"content-length-range", float64(0), float64(5 << 20)})
+		assert.Contains(t, policy.Conditions, map[string]interface{}{"bucket": "test-bucket"})
+
+		// Recalcula a assinatura SigV4 de forma independente
+		date := result.Fields["x-amz-date"][:8]
+		key := []byte("AWS4secret")
+		for _, part := range []string{date, "us-east-1", "s3", "aws4_request"} {
+			mac := hmac.New(sha256.New, key)
+			mac.Write([]byte(part))
+			key = mac.Sum(nil)
+		}
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(result.Fields["policy"]))
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), result.Fields["x-amz-signature"])
+	})
+
+	t.Run("Enforce minimum content length without maximum", func(t *testing.T) {
+		presignCtx := loadPresignContext(t, "")
+
+		result, err := presignCtx.PresignPost("test-bucket", PostPolicy{
+			Key:              "uploads/report.pdf",
+			MinContentLength: 1024,
+			Expiry:           time.Minute,
+		})
+		assert.NoError(t, err)
+
+		document, err := base64.StdEncoding.DecodeString(result.Fields["policy"])
+		assert.NoError(t, err)
+
+		var policy struct {
+			Conditions []interface{} `json:"conditions"`
+		}
+		assert.NoError(t, json.Unmarshal(document, &policy))
+		assert.Contains(t, policy.Conditions, []interface{}{"content-length-range", float64(1024), float64(5 << 30)})
+	})
+
+	t.Run("Reject policy without key", func(t *testing.T) {
+		presignCtx := loadPresignContext(t, "")
+
+		_, err := presignCtx.PresignPost("test-bucket", PostPolicy{Expiry: time.Minute})
+
+		assert.Error(t, err)
+	})
+}
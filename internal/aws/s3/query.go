@@ -0,0 +1,87 @@
+package s3
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+// applyQuery avalia a expressão JMESPath sobre o documento decodificado
+func applyQuery(expression string, value interface{}) (interface{}, error) {
+	if _, ok := value.(string); ok {
+		return nil, errors.New("query is only supported for structured documents")
+	}
+
+	compiled, err := jmespath.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JMESPath expression: %w", err)
+	}
+
+	result, err := compiled.Search(normalize(value))
+	if err != nil {
+		return nil, fmt.Errorf("error when evaluating JMESPath expression: %w", err)
+	}
+	return result, nil
+}
+
+// normalize converte os tipos produzidos pelos decoders para map[string]interface{} e
+// []interface{}, que são as únicas estruturas navegáveis pelo interpretador JMESPath
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = normalize(item)
+		}
+		return out
+
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[fmt.Sprint(key)] = normalize(item)
+		}
+		return out
+
+	case map[string]string:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = item
+		}
+		return out
+
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = normalize(item)
+		}
+		return out
+
+	case []map[string]string:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = normalize(item)
+		}
+		return out
+
+	case [][]string:
+		out := make([]interface{}, len(v))
+		for i, row := range v {
+			cells := make([]interface{}, len(row))
+			for j, cell := range row {
+				cells[j] = cell
+			}
+			out[i] = cells
+		}
+		return out
+
+	case int:
+		return float64(v)
+
+	case int64:
+		return float64(v)
+
+	default:
+		return v
+	}
+}
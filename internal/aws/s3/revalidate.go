@@ -0,0 +1,150 @@
+package s3
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	s3bucket "github.com/aws/aws-sdk-go/service/s3"
+)
+
+// maxCachedObjects limita o cache de Revalidate; ao atingir o limite o objeto usado há mais tempo é descartado
+const maxCachedObjects = 1000
+
+// cachedObject guarda o conteúdo e os validadores da última leitura de um objeto
+type cachedObject struct {
+	content      *objectContent
+	body         []byte
+	eTag         string
+	lastModified time.Time
+	lastUsed     time.Time
+}
+
+// objectCache armazena os objetos lidos por Revalidate, indexados por bucket, chave e versão
+type objectCache struct {
+	mutex   sync.Mutex
+	objects map[string]*cachedObject
+}
+
+// objectID identifica o objeto no cache; versionID vazio indica a versão mais recente
+func objectID(bucketName, keyName, versionID string) string {
+	return bucketName + "/" + keyName + "\x00" + versionID
+}
+
+func (c *objectCache) get(id string) *cachedObject {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	object, ok := c.objects[id]
+	if ok {
+		object.lastUsed = time.Now()
+	}
+	return object
+}
+
+func (c *objectCache) set(id string, object *cachedObject) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.objects == nil {
+		c.objects = make(map[string]*cachedObject)
+	}
+
+	if _, ok := c.objects[id]; !ok && len(c.objects) >= maxCachedObjects {
+		var oldestID string
+		var oldest time.Time
+		for cachedID, cached := range c.objects {
+			if oldestID == "" || cached.lastUsed.Before(oldest) {
+				oldestID, oldest = cachedID, cached.lastUsed
+			}
+		}
+		delete(c.objects, oldestID)
+	}
+	object.lastUsed = time.Now()
+	c.objects[id] = object
+}
+
+// deleteKey descarta todas as versões em cache da chave
+func (c *objectCache) deleteKey(bucketName, keyName string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	prefix := objectID(bucketName, keyName, "")
+	for id := range c.objects {
+		if strings.HasPrefix(id, prefix) {
+			delete(c.objects, id)
+		}
+	}
+}
+
+// Revalidate lê o objeto com uma requisição condicional (If-None-Match e If-Modified-Since)
+// baseada na última leitura; quando o S3 responde 304 Not Modified, o conteúdo em cache é
+// decodificado novamente sem novo download. O retorno changed indica se o objeto mudou desde
+// a leitura anterior e é sempre verdadeiro na primeira leitura
+func (ctx *S3CloudContext) Revalidate(bucketName, keyName string, opts ...GetOption) (value interface{}, changed bool, err error) {
+	options := newGetOptions(opts)
+	id := objectID(bucketName, keyName, options.VersionID)
+
+	input := &s3bucket.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(keyName),
+	}
+	if options.VersionID != "" {
+		input.VersionId = aws.String(options.VersionID)
+	}
+
+	cached := ctx.cache.get(id)
+	if cached != nil {
+		if cached.eTag != "" {
+			input.IfNoneMatch = aws.String(cached.eTag)
+		}
+		if !cached.lastModified.IsZero() {
+			input.IfModifiedSince = aws.Time(cached.lastModified)
+		}
+	}
+
+	result, err := ctx.svc.GetObject(input)
+	if err != nil {
+		if cached != nil && isNotModified(err) {
+			value, err := decodeObject(cached.content, cached.body, options)
+			return value, false, err
+		}
+		return nil, false, fmt.Errorf("error when obtaining S3 object: %w", err)
+	}
+
+	content, bodyBytes, err := ctx.readContent(bucketName, keyName, options.VersionID, result)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ctx.cache.set(id, &cachedObject{
+		content:      content,
+		body:         bodyBytes,
+		eTag:         aws.StringValue(result.ETag),
+		lastModified: aws.TimeValue(result.LastModified),
+	})
+
+	value, err = decodeObject(content, bodyBytes, options)
+	if err != nil {
+		return nil, true, err
+	}
+	return value, true, nil
+}
+
+// Invalidate descarta o conteúdo em cache do objeto, inclusive das versões lidas com WithVersionID,
+// forçando o download na próxima revalidação
+func (ctx *S3CloudContext) Invalidate(bucketName, keyName string) {
+	ctx.cache.deleteKey(bucketName, keyName)
+}
+
+func isNotModified(err error) bool {
+	var requestFailure awserr.RequestFailure
+	if errors.As(err, &requestFailure) {
+		return requestFailure.StatusCode() == http.StatusNotModified
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package s3
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestS3CloudContext_Revalidate(t *testing.T) {
+	lastModified := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	notModified := awserr.NewRequestFailure(awserr.New("NotModified", "Not Modified", nil), 304, "request-id")
+
+	t.Run("Return cached value when not modified", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+			return input.IfNoneMatch == nil
+		})).Return(&s3.GetObjectOutput{
+			Body:         io.NopCloser(strings.NewReader(`{"version": 1}`)),
+			ETag:         aws.String(`"v1"`),
+			LastModified: aws.Time(lastModified),
+		}, nil).Once()
+
+		mockS3.On("GetObject", mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+			return aws.StringValue(input.IfNoneMatch) == `"v1"` && aws.TimeValue(input.IfModifiedSince).Equal(lastModified)
+		})).Return((*s3.GetObjectOutput)(nil), notModified).Once()
+
+		value, changed, err := ctx.Revalidate("test-bucket", "config.json")
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, map[string]interface{}{"version": float64(1)}, value)
+
+		value, changed, err = ctx.Revalidate("test-bucket", "config.json", WithQuery("version"))
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, float64(1), value)
+
+		mockS3.AssertExpectations(t)
+	})
+
+	t.Run("Download again when modified", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(strings.NewReader(`{"version": 1}`)),
+			ETag: aws.String(`"v1"`),
+		}, nil).Once()
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(strings.NewReader(`{"version": 2}`)),
+			ETag: aws.String(`"v2"`),
+		}, nil).Once()
+
+		_, _, err := ctx.Revalidate("test-bucket", "config.json")
+		assert.NoError(t, err)
+
+		value, changed, err := ctx.Revalidate("test-bucket", "config.json")
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, map[string]interface{}{"version": float64(2)}, value)
+	})
+
+	t.Run("Not modified without cache is an error", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return((*s3.GetObjectOutput)(nil), notModified)
+
+		_, _, err := ctx.Revalidate("test-bucket", "config.json")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Revalidate a specific version", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+			return input.VersionId == nil
+		})).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(strings.NewReader(`{"version": 2}`)),
+			ETag: aws.String(`"v2"`),
+		}, nil).Once()
+		mockS3.On("GetObject", mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+			return aws.StringValue(input.VersionId) == "old" && input.IfNoneMatch == nil
+		})).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(strings.NewReader(`{"version": 1}`)),
+			ETag: aws.String(`"v1"`),
+		}, nil).Once()
+		mockS3.On("GetObject", mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+			return aws.StringValue(input.VersionId) == "old" && aws.StringValue(input.IfNoneMatch) == `"v1"`
+		})).Return((*s3.GetObjectOutput)(nil), notModified).Once()
+
+		value, _, err := ctx.Revalidate("test-bucket", "config.json")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"version": float64(2)}, value)
+
+		for i := 0; i < 2; i++ {
+			value, _, err = ctx.Revalidate("test-bucket", "config.json", WithVersionID("old"))
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"version": float64(1)}, value)
+		}
+		mockS3.AssertExpectations(t)
+	})
+
+	t.Run("Cache is bounded", func(t *testing.T) {
+		loadDefaultVariables()
+
+		for i := 0; i < maxCachedObjects+10; i++ {
+			ctx.cache.set(objectID("test-bucket", fmt.Sprintf("config-%d.json", i), ""), &cachedObject{})
+		}
+
+		assert.Len(t, ctx.cache.objects, maxCachedObjects)
+		assert.NotNil(t, ctx.cache.get(objectID("test-bucket", fmt.Sprintf("config-%d.json", maxCachedObjects+9), "")))
+	})
+
+	t.Run("Invalidate cached object", func(t *testing.T) {
+		loadDefaultVariables()
+
+		for i := 0; i < 2; i++ {
+			mockS3.On("GetObject", mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+				return input.IfNoneMatch == nil
+			})).Return(&s3.GetObjectOutput{
+				Body: io.NopCloser(strings.NewReader(`{"version": 1}`)),
+				ETag: aws.String(`"v1"`),
+			}, nil).Once()
+		}
+
+		_, _, err := ctx.Revalidate("test-bucket", "config.json")
+		assert.NoError(t, err)
+
+		ctx.Invalidate("test-bucket", "config.json")
+
+		_, changed, err := ctx.Revalidate("test-bucket", "config.json")
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		mockS3.AssertExpectations(t)
+	})
+}
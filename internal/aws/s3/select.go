@@ -0,0 +1,215 @@
+package s3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	s3bucket "github.com/aws/aws-sdk-go/service/s3"
+)
+
+// SelectIterator percorre os registros retornados pelo S3 Select à medida que chegam
+type SelectIterator struct {
+	stream  *s3bucket.SelectObjectContentEventStream
+	reader  *io.PipeReader
+	decoder *json.Decoder
+	headers []string
+	columns map[string]ColumnType
+	layout  string
+	count   int
+}
+
+// Select executa a consulta SQL do S3 Select sobre objetos CSV, JSON, NDJSON ou Parquet;
+// os registros são sempre devolvidos como mapas, aplicando as opções de CSV da leitura comum.
+// A consulta é executada no S3 sem que o cliente veja o objeto inteiro, por isso não é possível verificar a
+// assinatura nem consultar conteúdo cifrado: a consulta é recusada com a verificação ou a criptografia
+// habilitadas e em objetos gravados com criptografia de envelope
+func (ctx *S3CloudContext) Select(bucketName, keyName, expression string, inputFormat Format, opts ...GetOption) (*SelectIterator, error) {
+	if ctx.signatureSettings() != nil {
+		return nil, errors.New("signature verification is not supported for S3 select, use GetValue")
+	}
+	if ctx.encryptionSettings() != nil {
+		return nil, errors.New("client-side encryption is not supported for S3 select, use GetValue")
+	}
+	options := newGetOptions(opts)
+
+	inputSerialization, err := selectInputSerialization(keyName, inputFormat, options.CSV)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := ctx.svc.HeadObject(&s3bucket.HeadObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(keyName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when obtaining S3 object metadata: %w", err)
+	}
+	if metadataValue(head.Metadata, metadataCipher) != "" {
+		return nil, fmt.Errorf("%s is encrypted on the client side and cannot be queried with S3 select", keyName)
+	}
+
+	input := &s3bucket.SelectObjectContentInput{
+		Bucket:             aws.String(bucketName),
+		Key:                aws.String(keyName),
+		Expression:         aws.String(expression),
+		ExpressionType:     aws.String(s3bucket.ExpressionTypeSql),
+		InputSerialization: inputSerialization,
+		OutputSerialization: &s3bucket.OutputSerialization{
+			JSON: &s3bucket.JSONOutput{RecordDelimiter: aws.String("\n")},
+		},
+	}
+
+	result, err := ctx.svc.SelectObjectContent(input)
+	if err != nil {
+		return nil, fmt.Errorf("error when selecting S3 object content: %w", err)
+	}
+
+	reader, writer := io.Pipe()
+	go pipeSelectEvents(result.EventStream, writer)
+
+	iterator := &SelectIterator{
+		stream:  result.EventStream,
+		reader:  reader,
+		decoder: json.NewDecoder(reader),
+	}
+	if inputFormat == FormatCSV && options.CSV != nil {
+		iterator.headers = options.CSV.Headers
+		iterator.columns = options.CSV.Columns
+		iterator.layout = options.CSV.TimeLayout
+	}
+	return iterator, nil
+}
+
+// pipeSelectEvents repassa o conteúdo dos eventos de registros para o decoder; um registro
+// pode chegar dividido entre eventos, por isso o conteúdo é tratado como um fluxo contínuo
+func pipeSelectEvents(stream *s3bucket.SelectObjectContentEventStream, writer *io.PipeWriter) {
+	for event := range stream.Events() {
+		switch e := event.(type) {
+		case *s3bucket.RecordsEvent:
+			if _, err := writer.Write(e.Payload); err != nil {
+				return
+			}
+		case *s3bucket.EndEvent:
+			writer.Close()
+			return
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		writer.CloseWithError(fmt.Errorf("error when reading S3 select stream: %w", err))
+		return
+	}
+	writer.CloseWithError(errors.New("error when reading S3 select stream: the stream ended before the end event"))
+}
+
+// Next retorna o próximo registro selecionado; io.EOF indica o fim da consulta
+func (it *SelectIterator) Next() (map[string]interface{}, error) {
+	var record map[string]interface{}
+	if err := it.decoder.Decode(&record); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("error when analyzing S3 select record: %w", err)
+	}
+	it.count++
+
+	// Sem cabeçalho o S3 Select nomeia as colunas como _1, _2, ...
+	for i, header := range it.headers {
+		position := "_" + strconv.Itoa(i+1)
+		if value, ok := record[position]; ok {
+			delete(record, position)
+			record[header] = value
+		}
+	}
+
+	for column, columnType := range it.columns {
+		cell, ok := record[column].(string)
+		if !ok || columnType == ColumnString {
+			continue
+		}
+		if cell == "" {
+			record[column] = nil
+			continue
+		}
+
+		value, err := convertCell(cell, columnType, it.layout)
+		if err != nil {
+			return nil, fmt.Errorf("error when analyzing S3 select record %d, column %q: %w", it.count, column, err)
+		}
+		record[column] = value
+	}
+	return record, nil
+}
+
+// Close interrompe a consulta e libera a conexão com o S3
+func (it *SelectIterator) Close() error {
+	it.reader.Close()
+	return it.stream.Close()
+}
+
+// selectInputSerialization converte o formato e as opções de CSV para a configuração do S3 Select
+func selectInputSerialization(keyName string, inputFormat Format, csvOptions *CSVOptions) (*s3bucket.InputSerialization, error) {
+	serialization := &s3bucket.InputSerialization{
+		CompressionType: aws.String(s3bucket.CompressionTypeNone),
+	}
+
+	compression, _ := detectCompression(keyName, "", "")
+	switch compression {
+	case CompressionGzip:
+		serialization.CompressionType = aws.String(s3bucket.CompressionTypeGzip)
+	case CompressionBzip2:
+		serialization.CompressionType = aws.String(s3bucket.CompressionTypeBzip2)
+	case CompressionZstd:
+		return nil, errors.New("S3 select does not support zstd compressed objects")
+	}
+
+	switch inputFormat {
+	case FormatCSV:
+		csvInput := &s3bucket.CSVInput{
+			FileHeaderInfo: aws.String(s3bucket.FileHeaderInfoUse),
+		}
+		if csvOptions != nil {
+			if csvOptions.Delimiter != 0 {
+				csvInput.FieldDelimiter = aws.String(string(csvOptions.Delimiter))
+			}
+			if csvOptions.Comment != 0 {
+				csvInput.Comments = aws.String(string(csvOptions.Comment))
+			}
+			if csvOptions.NoHeader || len(csvOptions.Headers) > 0 {
+				csvInput.FileHeaderInfo = aws.String(s3bucket.FileHeaderInfoNone)
+			}
+			// O S3 Select não tem equivalente ao LazyQuotes, ao TrimLeadingSpace e ao AllowRaggedRows
+			// da leitura comum, e aceitar as opções faria a mesma configuração produzir resultados
+			// diferentes de GetValue
+			if csvOptions.LazyQuotes {
+				return nil, errors.New("S3 select does not support lazy quotes in CSV objects")
+			}
+			if csvOptions.TrimLeadingSpace {
+				return nil, errors.New("S3 select does not support trimming leading spaces in CSV objects")
+			}
+			if csvOptions.AllowRaggedRows {
+				return nil, errors.New("S3 select does not support ragged rows in CSV objects")
+			}
+		}
+		serialization.CSV = csvInput
+
+	case FormatJSON:
+		serialization.JSON = &s3bucket.JSONInput{Type: aws.String(s3bucket.JSONTypeDocument)}
+
+	case FormatNDJSON:
+		serialization.JSON = &s3bucket.JSONInput{Type: aws.String(s3bucket.JSONTypeLines)}
+
+	case FormatParquet:
+		// O S3 Select não aceita compressão externa em objetos Parquet
+		serialization.CompressionType = nil
+		serialization.Parquet = &s3bucket.ParquetInput{}
+
+	default:
+		return nil, fmt.Errorf("unsupported S3 select input format: %s", inputFormat)
+	}
+	return serialization, nil
+}
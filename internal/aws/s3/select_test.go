@@ -0,0 +1,183 @@
+package s3
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockSelectReader entrega eventos pré-definidos no lugar da conexão com o S3
+type mockSelectReader struct {
+	events chan s3.SelectObjectContentEventStreamEvent
+	err    error
+}
+
+func newMockSelectReader(err error, events ...s3.SelectObjectContentEventStreamEvent) *mockSelectReader {
+	reader := &mockSelectReader{
+		events: make(chan s3.SelectObjectContentEventStreamEvent, len(events)),
+		err:    err,
+	}
+	for _, event := range events {
+		reader.events <- event
+	}
+	close(reader.events)
+	return reader
+}
+
+func (r *mockSelectReader) Events() <-chan s3.SelectObjectContentEventStreamEvent {
+	return r.events
+}
+
+func (r *mockSelectReader) Close() error {
+	return nil
+}
+
+func (r *mockSelectReader) Err() error {
+	return r.err
+}
+
+func mockSelect(matcher interface{}, reader *mockSelectReader) {
+	stream := s3.NewSelectObjectContentEventStream(func(es *s3.SelectObjectContentEventStream) {
+		es.Reader = reader
+		es.StreamCloser = io.NopCloser(nil)
+	})
+	mockS3.On("HeadObject", mock.Anything).Return(&s3.HeadObjectOutput{}, nil)
+	mockS3.On("SelectObjectContent", matcher).Return(&s3.SelectObjectContentOutput{
+		EventStream: stream,
+	}, nil)
+}
+
+func TestS3CloudContext_Select(t *testing.T) {
+	t.Run("Select CSV records with typed columns", func(t *testing.T) {
+		loadDefaultVariables()
+
+		// O segundo registro chega dividido entre dois eventos
+		mockSelect(mock.MatchedBy(func(input *s3.SelectObjectContentInput) bool {
+			return aws.StringValue(input.InputSerialization.CSV.FieldDelimiter) == ";" &&
+				aws.StringValue(input.InputSerialization.CompressionType) == "GZIP" &&
+				aws.StringValue(input.Expression) == "SELECT * FROM s3object s WHERE s.age > '20'"
+		}), newMockSelectReader(nil,
+			&s3.RecordsEvent{Payload: []byte("{\"name\":\"Alice\",\"age\":\"30\"}\n{\"name\":")},
+			&s3.StatsEvent{},
+			&s3.RecordsEvent{Payload: []byte("\"Bob\",\"age\":\"25\"}\n")},
+			&s3.EndEvent{},
+		))
+
+		it, err := ctx.Select("test-bucket", "people.csv.gz", "SELECT * FROM s3object s WHERE s.age > '20'", FormatCSV,
+			WithCSVOptions(CSVOptions{Delimiter: ';', Columns: map[string]ColumnType{"age": ColumnInt}}))
+		assert.NoError(t, err)
+		defer it.Close()
+
+		records := make([]map[string]interface{}, 0)
+		for {
+			record, err := it.Next()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			records = append(records, record)
+		}
+
+		assert.Equal(t, []map[string]interface{}{
+			{"name": "Alice", "age": int64(30)},
+			{"name": "Bob", "age": int64(25)},
+		}, records)
+	})
+
+	t.Run("Select headerless CSV with column names", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockSelect(mock.MatchedBy(func(input *s3.SelectObjectContentInput) bool {
+			return aws.StringValue(input.InputSerialization.CSV.FileHeaderInfo) == "NONE"
+		}), newMockSelectReader(nil,
+			&s3.RecordsEvent{Payload: []byte("{\"_1\":\"1\",\"_2\":\"Alice\"}\n")},
+			&s3.EndEvent{},
+		))
+
+		it, err := ctx.Select("test-bucket", "people.csv", "SELECT * FROM s3object", FormatCSV,
+			WithCSVOptions(CSVOptions{NoHeader: true, Headers: []string{"id", "name"}}))
+		assert.NoError(t, err)
+		defer it.Close()
+
+		record, err := it.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"id": "1", "name": "Alice"}, record)
+	})
+
+	t.Run("Select NDJSON records", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockSelect(mock.MatchedBy(func(input *s3.SelectObjectContentInput) bool {
+			return aws.StringValue(input.InputSerialization.JSON.Type) == "LINES"
+		}), newMockSelectReader(nil,
+			&s3.RecordsEvent{Payload: []byte("{\"id\":1}\n")},
+			&s3.EndEvent{},
+		))
+
+		it, err := ctx.Select("test-bucket", "events.ndjson", "SELECT * FROM s3object", FormatNDJSON)
+		assert.NoError(t, err)
+		defer it.Close()
+
+		record, err := it.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"id": float64(1)}, record)
+
+		_, err = it.Next()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("Stream error", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockSelect(mock.Anything, newMockSelectReader(errors.New("connection reset"),
+			&s3.RecordsEvent{Payload: []byte("{\"id\":1}\n")},
+		))
+
+		it, err := ctx.Select("test-bucket", "data.parquet", "SELECT * FROM s3object", FormatParquet)
+		assert.NoError(t, err)
+		defer it.Close()
+
+		_, err = it.Next()
+		assert.NoError(t, err)
+
+		_, err = it.Next()
+		assert.ErrorContains(t, err, "connection reset")
+	})
+
+	t.Run("Reject lazy quotes", func(t *testing.T) {
+		loadDefaultVariables()
+
+		_, err := ctx.Select("test-bucket", "people.csv", "SELECT * FROM s3object", FormatCSV,
+			WithCSVOptions(CSVOptions{LazyQuotes: true}))
+
+		assert.ErrorContains(t, err, "lazy quotes")
+		mockS3.AssertNotCalled(t, "SelectObjectContent", mock.Anything)
+	})
+
+	t.Run("Reject CSV options S3 select cannot express", func(t *testing.T) {
+		loadDefaultVariables()
+
+		_, err := ctx.Select("test-bucket", "people.csv", "SELECT * FROM s3object", FormatCSV,
+			WithCSVOptions(CSVOptions{TrimLeadingSpace: true}))
+		assert.ErrorContains(t, err, "leading spaces")
+
+		_, err = ctx.Select("test-bucket", "people.csv", "SELECT * FROM s3object", FormatCSV,
+			WithCSVOptions(CSVOptions{AllowRaggedRows: true}))
+		assert.ErrorContains(t, err, "ragged rows")
+		mockS3.AssertNotCalled(t, "SelectObjectContent", mock.Anything)
+	})
+
+	t.Run("Unsupported input format", func(t *testing.T) {
+		loadDefaultVariables()
+
+		_, err := ctx.Select("test-bucket", "data.yaml", "SELECT * FROM s3object", FormatYAML)
+
+		assert.Error(t, err)
+		mockS3.AssertNotCalled(t, "SelectObjectContent", mock.Anything)
+	})
+}
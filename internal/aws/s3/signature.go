@@ -0,0 +1,347 @@
+package s3
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/kms"
+	s3bucket "github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+	metadataSignature         = "Cec-Signature"
+	metadataSignatureSequence = "Cec-Signature-Sequence"
+	signatureExtension        = ".sig"
+	signaturePayloadVersion   = "CEC-S3-SIGNATURE-V1"
+)
+
+// SignatureLocation indica onde a assinatura destacada do objeto é armazenada
+type SignatureLocation int
+
+const (
+	// SignatureSibling armazena a assinatura em um objeto irmão com o sufixo .sig, um por versão
+	// do objeto; em buckets versionados a assinatura também cobre o VersionId gravado
+	SignatureSibling SignatureLocation = iota
+	// SignatureMetadata armazena a assinatura no metadado Cec-Signature do próprio objeto
+	SignatureMetadata
+)
+
+// ErrInvalidSignature indica que o conteúdo não corresponde à assinatura ou que ela não existe
+var ErrInvalidSignature = errors.New("invalid S3 object signature")
+
+// Signer assina o payload gravado no S3, que vincula o conteúdo ao bucket, à chave, à versão e
+// à sequência da gravação
+type Signer interface {
+	Sign(content []byte) ([]byte, error)
+}
+
+// Verifier valida a assinatura do payload lido do S3
+type Verifier interface {
+	Verify(content, signature []byte) error
+}
+
+// KMSSignatureResource representa as operações de assinatura assimétrica do KMS
+type KMSSignatureResource interface {
+	Sign(input *kms.SignInput) (*kms.SignOutput, error)
+	Verify(input *kms.VerifyInput) (*kms.VerifyOutput, error)
+}
+
+type ed25519Signer struct {
+	privateKey ed25519.PrivateKey
+}
+
+// NewEd25519Signer cria um Signer com a chave privada Ed25519 informada
+func NewEd25519Signer(privateKey ed25519.PrivateKey) Signer {
+	return &ed25519Signer{privateKey: privateKey}
+}
+
+func (s *ed25519Signer) Sign(content []byte) ([]byte, error) {
+	if len(s.privateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid Ed25519 private key")
+	}
+	return ed25519.Sign(s.privateKey, content), nil
+}
+
+type ed25519Verifier struct {
+	publicKey ed25519.PublicKey
+}
+
+// NewEd25519Verifier cria um Verifier com a chave pública Ed25519 informada
+func NewEd25519Verifier(publicKey ed25519.PublicKey) Verifier {
+	return &ed25519Verifier{publicKey: publicKey}
+}
+
+func (v *ed25519Verifier) Verify(content, signature []byte) error {
+	if len(v.publicKey) != ed25519.PublicKeySize {
+		return errors.New("invalid Ed25519 public key")
+	}
+	if !ed25519.Verify(v.publicKey, content, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// kmsSignature assina e verifica o hash do conteúdo com uma chave assimétrica do KMS, o que evita
+// o limite de 4 KB de mensagens enviadas diretamente ao KMS; o hash segue o algoritmo de assinatura
+type kmsSignature struct {
+	svc       KMSSignatureResource
+	keyID     string
+	algorithm string
+}
+
+// NewKMSSigner cria um Signer que usa a chave assimétrica do KMS e o algoritmo informados (ex.: ECDSA_SHA_256)
+func NewKMSSigner(svc KMSSignatureResource, keyID, algorithm string) Signer {
+	return &kmsSignature{svc: svc, keyID: keyID, algorithm: algorithm}
+}
+
+// NewKMSVerifier cria um Verifier que usa a chave assimétrica do KMS e o algoritmo informados
+func NewKMSVerifier(svc KMSSignatureResource, keyID, algorithm string) Verifier {
+	return &kmsSignature{svc: svc, keyID: keyID, algorithm: algorithm}
+}
+
+func (k *kmsSignature) Sign(content []byte) ([]byte, error) {
+	result, err := k.svc.Sign(&kms.SignInput{
+		KeyId:            aws.String(k.keyID),
+		Message:          k.digest(content),
+		MessageType:      aws.String(kms.MessageTypeDigest),
+		SigningAlgorithm: aws.String(k.algorithm),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when signing with KMS: %w", err)
+	}
+	return result.Signature, nil
+}
+
+func (k *kmsSignature) Verify(content, signature []byte) error {
+	result, err := k.svc.Verify(&kms.VerifyInput{
+		KeyId:            aws.String(k.keyID),
+		Message:          k.digest(content),
+		MessageType:      aws.String(kms.MessageTypeDigest),
+		Signature:        signature,
+		SigningAlgorithm: aws.String(k.algorithm),
+	})
+	if err != nil {
+		// O KMS responde KMSInvalidSignatureException para assinaturas que não conferem
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == kms.ErrCodeKMSInvalidSignatureException {
+			return ErrInvalidSignature
+		}
+		return fmt.Errorf("error when verifying signature with KMS: %w", err)
+	}
+	if !aws.BoolValue(result.SignatureValid) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// digest calcula o hash exigido pelo algoritmo, já que o KMS recusa digests de outro tamanho
+func (k *kmsSignature) digest(content []byte) []byte {
+	switch {
+	case strings.HasSuffix(k.algorithm, "SHA_384"):
+		digest := sha512.Sum384(content)
+		return digest[:]
+	case strings.HasSuffix(k.algorithm, "SHA_512"):
+		digest := sha512.Sum512(content)
+		return digest[:]
+	default:
+		digest := sha256.Sum256(content)
+		return digest[:]
+	}
+}
+
+// signatureConfig define como as assinaturas dos objetos lidos são localizadas e verificadas
+type signatureConfig struct {
+	verifier Verifier
+	location SignatureLocation
+
+	mutex     sync.Mutex
+	sequences map[string]int64
+}
+
+// advance registra a sequência verificada para o objeto, recusando sequências menores que a
+// maior já vista, o que indica que uma revisão assinada mais antiga foi restaurada
+func (c *signatureConfig) advance(id string, sequence int64) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if sequence < c.sequences[id] {
+		return false
+	}
+	c.sequences[id] = sequence
+	return true
+}
+
+// EnableSignatureVerification passa a exigir uma assinatura válida em todos os objetos lidos,
+// verificada sobre o conteúdo exatamente como armazenado, antes de decifrar ou descomprimir.
+// A assinatura só é aceita no bucket e na chave em que foi gravada, e leituras sem versão
+// explícita recusam revisões com sequência menor que a da última leitura verificada neste contexto
+func (ctx *S3CloudContext) EnableSignatureVerification(verifier Verifier, location SignatureLocation) error {
+	if verifier == nil {
+		return errors.New("the signature verifier is required")
+	}
+
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	ctx.signature = &signatureConfig{
+		verifier:  verifier,
+		location:  location,
+		sequences: make(map[string]int64),
+	}
+	return nil
+}
+
+// DisableSignatureVerification deixa de exigir assinaturas nos objetos lidos
+func (ctx *S3CloudContext) DisableSignatureVerification() {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	ctx.signature = nil
+}
+
+// signatureSettings retorna a configuração de verificação de assinaturas vigente
+func (ctx *S3CloudContext) signatureSettings() *signatureConfig {
+	ctx.mutex.RLock()
+	defer ctx.mutex.RUnlock()
+	return ctx.signature
+}
+
+// WithSignature assina o conteúdo gravado e armazena a assinatura no local informado
+func WithSignature(signer Signer, location SignatureLocation) PutOption {
+	return func(o *PutOptions) {
+		o.Signer = signer
+		o.SignatureLocation = location
+	}
+}
+
+// SignatureKey retorna a chave do objeto irmão que armazena a assinatura destacada da versão
+// informada; versionID vazio corresponde a buckets sem versionamento
+func SignatureKey(keyName, versionID string) string {
+	if versionID == "" {
+		return keyName + signatureExtension
+	}
+	return keyName + "." + versionID + signatureExtension
+}
+
+// isSignatureKey indica se a chave corresponde a um objeto irmão de assinatura
+func isSignatureKey(keyName string) bool {
+	return strings.HasSuffix(keyName, signatureExtension)
+}
+
+// signaturePayload monta o conteúdo efetivamente assinado: o hash do corpo armazenado junto com
+// o bucket, a chave, a versão e a sequência, para que a assinatura não possa ser reaproveitada
+// em outra chave ou versão
+func signaturePayload(bucketName, keyName, versionID string, sequence int64, content []byte) []byte {
+	digest := sha256.Sum256(content)
+	return []byte(fmt.Sprintf("%s\n%s\n%q\n%s\n%d\n%x",
+		signaturePayloadVersion, bucketName, keyName, versionID, sequence, digest))
+}
+
+var (
+	sequenceMutex sync.Mutex
+	lastSequence  int64
+)
+
+// newSignatureSequence gera a sequência monotônica da gravação a partir do relógio, sempre
+// maior que a anterior mesmo quando duas gravações caem no mesmo instante
+func newSignatureSequence() int64 {
+	sequenceMutex.Lock()
+	defer sequenceMutex.Unlock()
+
+	sequence := time.Now().UnixNano()
+	if sequence <= lastSequence {
+		sequence = lastSequence + 1
+	}
+	lastSequence = sequence
+	return sequence
+}
+
+// EncodeSignature converte a assinatura para o texto gravado no objeto irmão ou no metadado
+func EncodeSignature(signature []byte) string {
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+// verifyResult lê o corpo do objeto, valida a assinatura e devolve o conteúdo para as próximas etapas;
+// versionID é a versão pedida explicitamente pelo chamador, vazia na leitura da versão mais recente
+func (ctx *S3CloudContext) verifyResult(bucketName, keyName, versionID string, result *s3bucket.GetObjectOutput) error {
+	config := ctx.signatureSettings()
+	if config == nil {
+		return nil
+	}
+	defer result.Body.Close()
+
+	content, err := io.ReadAll(result.Body)
+	if err != nil {
+		return fmt.Errorf("error reading file content: %w", err)
+	}
+
+	sequence, err := strconv.ParseInt(metadataValue(result.Metadata, metadataSignatureSequence), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: signature sequence not found for %s", ErrInvalidSignature, keyName)
+	}
+
+	var encoded, signedVersion string
+	switch config.location {
+	case SignatureMetadata:
+		encoded = metadataValue(result.Metadata, metadataSignature)
+
+	default:
+		// A assinatura irmã é gravada depois do objeto e cobre a versão criada por ele
+		signedVersion = aws.StringValue(result.VersionId)
+		sibling, err := ctx.svc.GetObject(&s3bucket.GetObjectInput{
+			Bucket: aws.String(bucketName),
+			Key:    aws.String(SignatureKey(keyName, signedVersion)),
+		})
+		if err != nil {
+			return fmt.Errorf("error when obtaining S3 object signature: %w", err)
+		}
+		defer sibling.Body.Close()
+
+		signatureBytes, err := io.ReadAll(sibling.Body)
+		if err != nil {
+			return fmt.Errorf("error when reading S3 object signature: %w", err)
+		}
+		encoded = string(signatureBytes)
+	}
+
+	encoded = strings.TrimSpace(encoded)
+	if encoded == "" {
+		return fmt.Errorf("%w: signature not found for %s", ErrInvalidSignature, keyName)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature for %s", ErrInvalidSignature, keyName)
+	}
+
+	payload := signaturePayload(bucketName, keyName, signedVersion, sequence, content)
+	if err := config.verifier.Verify(payload, signature); err != nil {
+		return fmt.Errorf("error when verifying %s: %w", keyName, err)
+	}
+
+	// Versões antigas pedidas explicitamente são legítimas e não contam para a detecção de rollback
+	if versionID == "" && !config.advance(bucketName+"/"+keyName, sequence) {
+		return fmt.Errorf("%w: %s was replaced by an older signed revision", ErrInvalidSignature, keyName)
+	}
+
+	result.Body = io.NopCloser(bytes.NewReader(content))
+	return nil
+}
+
+// signPayload assina o payload do objeto gravado, retornando a assinatura codificada
+func signPayload(signer Signer, payload []byte) (string, error) {
+	signature, err := signer.Sign(payload)
+	if err != nil {
+		return "", fmt.Errorf("error when signing S3 object: %w", err)
+	}
+	return EncodeSignature(signature), nil
+}
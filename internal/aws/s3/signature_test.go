@@ -0,0 +1,311 @@
+package s3
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockKMSSignatureClient struct {
+	mock.Mock
+}
+
+func (m *mockKMSSignatureClient) Sign(input *kms.SignInput) (*kms.SignOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*kms.SignOutput), args.Error(1)
+}
+
+func (m *mockKMSSignatureClient) Verify(input *kms.VerifyInput) (*kms.VerifyOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*kms.VerifyOutput), args.Error(1)
+}
+
+// capturePuts registra os objetos enviados ao S3, indexados pela chave
+func capturePuts() map[string]*s3.PutObjectInput {
+	puts := make(map[string]*s3.PutObjectInput)
+	mockS3.On("PutObject", mock.Anything).Run(func(args mock.Arguments) {
+		input := args.Get(0).(*s3.PutObjectInput)
+		puts[aws.StringValue(input.Key)] = input
+	}).Return(&s3.PutObjectOutput{}, nil)
+	return puts
+}
+
+func readBody(input *s3.PutObjectInput) []byte {
+	body, _ := io.ReadAll(input.Body)
+	input.Body = bytes.NewReader(body)
+	return body
+}
+
+// signedObject responde a leitura da chave com o corpo e os metadados gravados por PutValue
+func signedObject(input *s3.PutObjectInput, keyName, versionID string) *mock.Call {
+	return mockS3.On("GetObject", mock.MatchedBy(func(get *s3.GetObjectInput) bool {
+		return aws.StringValue(get.Key) == keyName && aws.StringValue(get.VersionId) == versionID
+	})).Return(&s3.GetObjectOutput{
+		Body:      io.NopCloser(bytes.NewReader(readBody(input))),
+		Metadata:  input.Metadata,
+		VersionId: aws.String(versionID),
+	}, nil)
+}
+
+func TestS3CloudContext_Signature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	t.Run("Sign and verify with sibling object", func(t *testing.T) {
+		loadDefaultVariables()
+		puts := capturePuts()
+
+		_, err := ctx.PutValue("config-bucket", "flags.json", map[string]interface{}{"beta": true},
+			WithSignature(NewEd25519Signer(privateKey), SignatureSibling))
+		assert.NoError(t, err)
+		assert.Contains(t, puts, "flags.json.sig")
+
+		signedObject(puts["flags.json"], "flags.json", "")
+		signedObject(puts["flags.json.sig"], "flags.json.sig", "")
+
+		assert.NoError(t, ctx.EnableSignatureVerification(NewEd25519Verifier(publicKey), SignatureSibling))
+		result, err := ctx.GetValue("config-bucket", "flags.json")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"beta": true}, result)
+	})
+
+	t.Run("Verify explicit version with its own sibling", func(t *testing.T) {
+		loadDefaultVariables()
+		puts := make(map[string]*s3.PutObjectInput)
+		mockS3.On("PutObject", mock.Anything).Run(func(args mock.Arguments) {
+			input := args.Get(0).(*s3.PutObjectInput)
+			puts[aws.StringValue(input.Key)] = input
+		}).Return(&s3.PutObjectOutput{VersionId: aws.String("v1")}, nil)
+
+		_, err := ctx.PutValue("config-bucket", "flags.json", map[string]interface{}{"beta": true},
+			WithSignature(NewEd25519Signer(privateKey), SignatureSibling))
+		assert.NoError(t, err)
+		assert.Contains(t, puts, "flags.json.v1.sig")
+
+		signedObject(puts["flags.json"], "flags.json", "v1")
+		signedObject(puts["flags.json.v1.sig"], "flags.json.v1.sig", "")
+
+		assert.NoError(t, ctx.EnableSignatureVerification(NewEd25519Verifier(publicKey), SignatureSibling))
+		result, err := ctx.GetValue("config-bucket", "flags.json", WithVersionID("v1"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"beta": true}, result)
+	})
+
+	t.Run("Reject tampered object signed in metadata", func(t *testing.T) {
+		loadDefaultVariables()
+		puts := capturePuts()
+
+		_, err := ctx.PutValue("config-bucket", "flags.json", map[string]interface{}{"beta": false},
+			WithSignature(NewEd25519Signer(privateKey), SignatureMetadata))
+		assert.NoError(t, err)
+		assert.NotContains(t, puts, "flags.json.sig")
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body:     io.NopCloser(bytes.NewReader([]byte(`{"beta":true}`))),
+			Metadata: puts["flags.json"].Metadata,
+		}, nil)
+
+		assert.NoError(t, ctx.EnableSignatureVerification(NewEd25519Verifier(publicKey), SignatureMetadata))
+		_, err = ctx.GetValue("config-bucket", "flags.json")
+
+		assert.True(t, errors.Is(err, ErrInvalidSignature))
+	})
+
+	t.Run("Reject signed object copied to another key", func(t *testing.T) {
+		loadDefaultVariables()
+		puts := capturePuts()
+
+		_, err := ctx.PutValue("config-bucket", "flags.json", map[string]interface{}{"beta": true},
+			WithSignature(NewEd25519Signer(privateKey), SignatureMetadata))
+		assert.NoError(t, err)
+
+		signedObject(puts["flags.json"], "admin.json", "")
+
+		assert.NoError(t, ctx.EnableSignatureVerification(NewEd25519Verifier(publicKey), SignatureMetadata))
+		_, err = ctx.GetValue("config-bucket", "admin.json")
+
+		assert.True(t, errors.Is(err, ErrInvalidSignature))
+	})
+
+	t.Run("Reject rollback to an older signed revision", func(t *testing.T) {
+		loadDefaultVariables()
+		signer := WithSignature(NewEd25519Signer(privateKey), SignatureMetadata)
+
+		var revisions []*s3.PutObjectInput
+		mockS3.On("PutObject", mock.Anything).Run(func(args mock.Arguments) {
+			revisions = append(revisions, args.Get(0).(*s3.PutObjectInput))
+		}).Return(&s3.PutObjectOutput{}, nil)
+
+		_, err := ctx.PutValue("config-bucket", "flags.json", map[string]interface{}{"beta": false}, signer)
+		assert.NoError(t, err)
+		_, err = ctx.PutValue("config-bucket", "flags.json", map[string]interface{}{"beta": true}, signer)
+		assert.NoError(t, err)
+
+		signedObject(revisions[1], "flags.json", "").Once()
+		signedObject(revisions[0], "flags.json", "").Once()
+
+		assert.NoError(t, ctx.EnableSignatureVerification(NewEd25519Verifier(publicKey), SignatureMetadata))
+		_, err = ctx.GetValue("config-bucket", "flags.json")
+		assert.NoError(t, err)
+
+		_, err = ctx.GetValue("config-bucket", "flags.json")
+		assert.True(t, errors.Is(err, ErrInvalidSignature))
+	})
+
+	t.Run("Reject unsigned object", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader([]byte(`{"beta":true}`))),
+		}, nil)
+
+		assert.NoError(t, ctx.EnableSignatureVerification(NewEd25519Verifier(publicKey), SignatureMetadata))
+		_, err := ctx.GetValue("config-bucket", "flags.json")
+
+		assert.True(t, errors.Is(err, ErrInvalidSignature))
+	})
+
+	t.Run("Verify streamed reads", func(t *testing.T) {
+		loadDefaultVariables()
+		puts := capturePuts()
+
+		_, err := ctx.PutValue("config-bucket", "flags.json", map[string]interface{}{"beta": true},
+			WithSignature(NewEd25519Signer(privateKey), SignatureMetadata))
+		assert.NoError(t, err)
+		signedObject(puts["flags.json"], "flags.json", "")
+
+		mockS3.On("GetObject", mock.MatchedBy(func(get *s3.GetObjectInput) bool {
+			return aws.StringValue(get.Key) == "tampered.json"
+		})).Return(&s3.GetObjectOutput{
+			Body:     io.NopCloser(bytes.NewReader([]byte(`{"beta":false}`))),
+			Metadata: puts["flags.json"].Metadata,
+		}, nil)
+		mockS3.On("GetObject", mock.MatchedBy(func(get *s3.GetObjectInput) bool {
+			return aws.StringValue(get.Key) == "unsigned.json"
+		})).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader([]byte(`{"beta":false}`))),
+		}, nil)
+
+		assert.NoError(t, ctx.EnableSignatureVerification(NewEd25519Verifier(publicKey), SignatureMetadata))
+
+		body, err := ctx.GetReader("config-bucket", "flags.json")
+		assert.NoError(t, err)
+		content, _ := io.ReadAll(body)
+		assert.JSONEq(t, `{"beta":true}`, string(content))
+
+		_, err = ctx.GetReader("config-bucket", "tampered.json")
+		assert.True(t, errors.Is(err, ErrInvalidSignature))
+		_, err = ctx.GetReader("config-bucket", "unsigned.json")
+		assert.True(t, errors.Is(err, ErrInvalidSignature))
+	})
+
+	t.Run("Refuse range reads and select while verifying", func(t *testing.T) {
+		loadDefaultVariables()
+
+		assert.NoError(t, ctx.EnableSignatureVerification(NewEd25519Verifier(publicKey), SignatureMetadata))
+
+		_, err := ctx.GetRange("config-bucket", "unsigned.json", 0, 10)
+		assert.Error(t, err)
+		_, err = ctx.Select("config-bucket", "unsigned.json", "SELECT * FROM s3object", FormatJSON)
+		assert.Error(t, err)
+		mockS3.AssertNotCalled(t, "GetObject", mock.Anything)
+		mockS3.AssertNotCalled(t, "SelectObjectContent", mock.Anything)
+	})
+
+	t.Run("Load prefix skips sibling signatures", func(t *testing.T) {
+		loadDefaultVariables()
+		puts := capturePuts()
+
+		_, err := ctx.PutValue("config-bucket", "flags/beta.json", map[string]interface{}{"beta": true},
+			WithSignature(NewEd25519Signer(privateKey), SignatureSibling))
+		assert.NoError(t, err)
+
+		mockS3.On("ListObjectsV2", mock.Anything).Return(&s3.ListObjectsV2Output{
+			Contents: []*s3.Object{
+				{Key: aws.String("flags/beta.json")},
+				{Key: aws.String("flags/beta.json.sig")},
+			},
+		}, nil)
+		signedObject(puts["flags/beta.json"], "flags/beta.json", "")
+		signedObject(puts["flags/beta.json.sig"], "flags/beta.json.sig", "")
+
+		assert.NoError(t, ctx.EnableSignatureVerification(NewEd25519Verifier(publicKey), SignatureSibling))
+		values, err := ctx.LoadPrefix("config-bucket", "flags/")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"flags/beta.json": map[string]interface{}{"beta": true}}, values)
+	})
+
+	t.Run("Toggle verification while reading", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockS3.On("GetObject", mock.Anything).Return(&s3.GetObjectOutput{
+			Body: io.NopCloser(bytes.NewReader(nil)),
+		}, nil)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				assert.NoError(t, ctx.EnableSignatureVerification(NewEd25519Verifier(publicKey), SignatureMetadata))
+				ctx.DisableSignatureVerification()
+			}
+		}()
+		for i := 0; i < 50; i++ {
+			_, _ = ctx.GetValue("config-bucket", "notes.txt")
+		}
+		wg.Wait()
+	})
+}
+
+func TestKMSSignature(t *testing.T) {
+	t.Run("Sign and verify digest", func(t *testing.T) {
+		client := new(mockKMSSignatureClient)
+		client.On("Sign", mock.MatchedBy(func(input *kms.SignInput) bool {
+			return aws.StringValue(input.MessageType) == "DIGEST" && len(input.Message) == 32
+		})).Return(&kms.SignOutput{Signature: []byte("kms-signature")}, nil)
+		client.On("Verify", mock.MatchedBy(func(input *kms.VerifyInput) bool {
+			return string(input.Signature) == "kms-signature"
+		})).Return(&kms.VerifyOutput{SignatureValid: aws.Bool(true)}, nil)
+
+		signature, err := NewKMSSigner(client, "alias/signing", "ECDSA_SHA_256").Sign([]byte("content"))
+		assert.NoError(t, err)
+
+		err = NewKMSVerifier(client, "alias/signing", "ECDSA_SHA_256").Verify([]byte("content"), signature)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		client := new(mockKMSSignatureClient)
+		client.On("Verify", mock.Anything).Return((*kms.VerifyOutput)(nil),
+			awserr.New(kms.ErrCodeKMSInvalidSignatureException, "invalid", nil))
+
+		err := NewKMSVerifier(client, "alias/signing", "ECDSA_SHA_256").Verify([]byte("content"), []byte("bad"))
+
+		assert.Equal(t, ErrInvalidSignature, err)
+	})
+
+	t.Run("Digest follows the signing algorithm", func(t *testing.T) {
+		client := new(mockKMSSignatureClient)
+		client.On("Sign", mock.MatchedBy(func(input *kms.SignInput) bool {
+			return len(input.Message) == 48
+		})).Return(&kms.SignOutput{Signature: []byte("kms-signature")}, nil)
+
+		_, err := NewKMSSigner(client, "alias/signing", "ECDSA_SHA_384").Sign([]byte("content"))
+
+		assert.NoError(t, err)
+	})
+}
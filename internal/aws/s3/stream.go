@@ -0,0 +1,284 @@
+package s3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	s3bucket "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// S3Uploader representa o envio multipart de objetos grandes
+type S3Uploader interface {
+	Upload(input *s3manager.UploadInput, options ...func(*s3manager.Uploader)) (*s3manager.UploadOutput, error)
+}
+
+// WithPartSize define o tamanho, em bytes, de cada parte do upload multipart
+func WithPartSize(partSize int64) PutOption {
+	return func(o *PutOptions) {
+		o.PartSize = partSize
+	}
+}
+
+// WithConcurrency define quantas partes do upload multipart são enviadas em paralelo
+func WithConcurrency(concurrency int) PutOption {
+	return func(o *PutOptions) {
+		o.Concurrency = concurrency
+	}
+}
+
+// GetReader retorna o corpo do objeto S3 sem carregá-lo em memória e sem descomprimi-lo; o chamador deve fechá-lo.
+// Com a verificação de assinatura habilitada, ou quando o objeto foi gravado com criptografia de envelope, o
+// objeto é lido, verificado e decifrado por completo antes de ser retornado
+func (ctx *S3CloudContext) GetReader(bucketName, keyName string) (io.ReadCloser, error) {
+	result, err := ctx.getObject(&s3bucket.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(keyName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.verifyResult(bucketName, keyName, "", result); err != nil {
+		return nil, err
+	}
+	if err := ctx.decryptResult(bucketName, keyName, result); err != nil {
+		return nil, err
+	}
+	return result.Body, nil
+}
+
+// GetRange retorna length bytes do objeto S3 a partir de offset; length menor ou igual a zero lê até o final.
+// Um trecho do objeto não pode ter a assinatura verificada nem ser decifrado, por isso a leitura é recusada com a
+// verificação ou a criptografia habilitadas e em objetos gravados com criptografia de envelope
+func (ctx *S3CloudContext) GetRange(bucketName, keyName string, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 {
+		return nil, fmt.Errorf("invalid range offset: %d", offset)
+	}
+	if ctx.signatureSettings() != nil {
+		return nil, errors.New("signature verification is not supported for range reads, use GetReader")
+	}
+	if ctx.encryptionSettings() != nil {
+		return nil, errors.New("client-side encryption is not supported for range reads, use GetReader")
+	}
+
+	byteRange := fmt.Sprintf("bytes=%d-", offset)
+	if length > 0 {
+		byteRange = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	}
+
+	result, err := ctx.getObject(&s3bucket.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(keyName),
+		Range:  aws.String(byteRange),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if metadataValue(result.Metadata, metadataCipher) != "" {
+		result.Body.Close()
+		return nil, fmt.Errorf("%s is encrypted on the client side and cannot be read by range, use GetReader", keyName)
+	}
+	return result.Body, nil
+}
+
+func (ctx *S3CloudContext) getObject(input *s3bucket.GetObjectInput) (*s3bucket.GetObjectOutput, error) {
+	result, err := ctx.svc.GetObject(input)
+	if err != nil {
+		return nil, fmt.Errorf("error when obtaining S3 object: %w", err)
+	}
+	return result, nil
+}
+
+// getContent abre o objeto para leitura incremental, descomprimindo-o quando necessário
+func (ctx *S3CloudContext) getContent(bucketName, keyName, versionID string) (io.ReadCloser, error) {
+	input := &s3bucket.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(keyName),
+	}
+	if versionID != "" {
+		input.VersionId = aws.String(versionID)
+	}
+
+	result, err := ctx.svc.GetObject(input)
+	if err != nil {
+		return nil, fmt.Errorf("error when obtaining S3 object: %w", err)
+	}
+	if err := ctx.verifyResult(bucketName, keyName, versionID, result); err != nil {
+		return nil, err
+	}
+	if err := ctx.decryptResult(bucketName, keyName, result); err != nil {
+		return nil, err
+	}
+
+	content, err := openContent(keyName, result)
+	if err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// CSVIterator percorre um objeto CSV linha a linha sem carregá-lo em memória
+type CSVIterator struct {
+	body   io.ReadCloser
+	parser *csvParser
+}
+
+// NewCSVIterator abre o objeto CSV e lê o cabeçalho sem carregar o restante do arquivo;
+// aceita as mesmas opções de CSV da leitura completa
+func (ctx *S3CloudContext) NewCSVIterator(bucketName, keyName string, opts ...GetOption) (*CSVIterator, error) {
+	options := newGetOptions(opts)
+	csvOptions := CSVOptions{}
+	if options.CSV != nil {
+		csvOptions = *options.CSV
+	}
+
+	body, err := ctx.getContent(bucketName, keyName, options.VersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	parser, err := newCSVParser(body, csvOptions)
+	if err != nil {
+		body.Close()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("error when analyzing CSV: missing header")
+		}
+		return nil, err
+	}
+
+	return &CSVIterator{
+		body:   body,
+		parser: parser,
+	}, nil
+}
+
+// Headers retorna o cabeçalho do arquivo CSV
+func (it *CSVIterator) Headers() []string {
+	return it.parser.headers
+}
+
+// Next retorna a próxima linha do arquivo; io.EOF indica o fim do objeto
+func (it *CSVIterator) Next() (map[string]string, error) {
+	if it.parser.headers == nil {
+		return nil, errors.New("error when analyzing CSV: headerless files must be read with NextRecord")
+	}
+
+	record, _, err := it.parser.next()
+	if err != nil {
+		return nil, err
+	}
+	return it.parser.toStrings(record), nil
+}
+
+// NextValues retorna a próxima linha convertendo as colunas tipadas; io.EOF indica o fim do objeto
+func (it *CSVIterator) NextValues() (map[string]interface{}, error) {
+	if it.parser.headers == nil {
+		return nil, errors.New("error when analyzing CSV: headerless files must be read with NextRecord")
+	}
+
+	record, line, err := it.parser.next()
+	if err != nil {
+		return nil, err
+	}
+	return it.parser.toValues(record, line)
+}
+
+// NextRecord retorna os campos crus da próxima linha; io.EOF indica o fim do objeto
+func (it *CSVIterator) NextRecord() ([]string, error) {
+	record, _, err := it.parser.next()
+	return record, err
+}
+
+// Close libera a conexão com o S3
+func (it *CSVIterator) Close() error {
+	return it.body.Close()
+}
+
+// NDJSONDecoder decodifica um objeto NDJSON registro a registro
+type NDJSONDecoder struct {
+	body    io.ReadCloser
+	decoder *json.Decoder
+}
+
+// NewNDJSONDecoder abre o objeto NDJSON para leitura incremental; aceita as opções de leitura, como WithVersionID
+func (ctx *S3CloudContext) NewNDJSONDecoder(bucketName, keyName string, opts ...GetOption) (*NDJSONDecoder, error) {
+	options := newGetOptions(opts)
+
+	body, err := ctx.getContent(bucketName, keyName, options.VersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NDJSONDecoder{
+		body:    body,
+		decoder: json.NewDecoder(body),
+	}, nil
+}
+
+// Next decodifica o próximo registro em v; io.EOF indica o fim do objeto
+func (d *NDJSONDecoder) Next(v interface{}) error {
+	if err := d.decoder.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.EOF
+		}
+		return fmt.Errorf("error when analyzing NDJSON: %w", err)
+	}
+	return nil
+}
+
+// Close libera a conexão com o S3
+func (d *NDJSONDecoder) Close() error {
+	return d.body.Close()
+}
+
+// Upload envia o conteúdo do reader em partes, sem precisar conhecer seu tamanho total
+func (ctx *S3CloudContext) Upload(bucketName, keyName string, body io.Reader, opts ...PutOption) (*PutResult, error) {
+	if ctx.encryptionSettings() != nil {
+		return nil, errors.New("client-side encryption is not supported for multipart uploads, use PutValue")
+	}
+	options := newPutOptions(opts)
+	if options.Signer != nil {
+		return nil, errors.New("signing is not supported for multipart uploads, use PutValue")
+	}
+
+	input := &s3manager.UploadInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(keyName),
+		Body:   body,
+	}
+	if options.ContentType != "" {
+		input.ContentType = aws.String(options.ContentType)
+	}
+	if options.SSEKMS {
+		input.ServerSideEncryption = aws.String(s3bucket.ServerSideEncryptionAwsKms)
+		if options.KMSKeyID != "" {
+			input.SSEKMSKeyId = aws.String(options.KMSKeyID)
+		}
+	}
+	if len(options.Metadata) > 0 {
+		input.Metadata = aws.StringMap(options.Metadata)
+	}
+	if len(options.Tags) > 0 {
+		input.Tagging = aws.String(encodeTags(options.Tags))
+	}
+
+	result, err := ctx.uploader.Upload(input, func(u *s3manager.Uploader) {
+		if options.PartSize > 0 {
+			u.PartSize = options.PartSize
+		}
+		if options.Concurrency > 0 {
+			u.Concurrency = options.Concurrency
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when uploading S3 object: %w", err)
+	}
+
+	return &PutResult{
+		ETag:      aws.StringValue(result.ETag),
+		VersionID: aws.StringValue(result.VersionID),
+	}, nil
+}
@@ -0,0 +1,304 @@
+package s3
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	s3bucket "github.com/aws/aws-sdk-go/service/s3"
+	"gopkg.in/yaml.v3"
+)
+
+// PutOption configura uma gravação individual de objeto S3
+type PutOption func(*PutOptions)
+
+// PutOptions reúne as configurações aplicadas em uma gravação de objeto S3
+type PutOptions struct {
+	ContentType   string
+	KMSKeyID      string
+	SSEKMS        bool
+	Metadata      map[string]string
+	Tags          map[string]string
+	PartSize      int64
+	Concurrency   int
+	ContentLength int64
+
+	Signer            Signer
+	SignatureLocation SignatureLocation
+}
+
+// PutResult contém os identificadores da versão gravada no S3
+type PutResult struct {
+	ETag      string
+	VersionID string
+}
+
+// WithContentType sobrescreve o Content-Type inferido pela extensão do arquivo
+func WithContentType(contentType string) PutOption {
+	return func(o *PutOptions) {
+		o.ContentType = contentType
+	}
+}
+
+// WithSSEKMS habilita a criptografia SSE-KMS; keyID vazio utiliza a chave gerenciada aws/s3
+func WithSSEKMS(keyID string) PutOption {
+	return func(o *PutOptions) {
+		o.SSEKMS = true
+		o.KMSKeyID = keyID
+	}
+}
+
+// WithMetadata adiciona metadados de usuário ao objeto gravado
+func WithMetadata(metadata map[string]string) PutOption {
+	return func(o *PutOptions) {
+		if o.Metadata == nil {
+			o.Metadata = make(map[string]string, len(metadata))
+		}
+		for key, value := range metadata {
+			o.Metadata[key] = value
+		}
+	}
+}
+
+// WithTags adiciona tags ao objeto gravado
+func WithTags(tags map[string]string) PutOption {
+	return func(o *PutOptions) {
+		if o.Tags == nil {
+			o.Tags = make(map[string]string, len(tags))
+		}
+		for key, value := range tags {
+			o.Tags[key] = value
+		}
+	}
+}
+
+func newPutOptions(opts []PutOption) *PutOptions {
+	options := &PutOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
+
+// PutValue codifica o valor de acordo com a extensão do arquivo e o grava no S3, cifrando-o
+// no cliente quando a criptografia de envelope estiver habilitada e assinando-o quando
+// WithSignature for informado
+func (ctx *S3CloudContext) PutValue(bucketName, keyName string, value interface{}, opts ...PutOption) (*PutResult, error) {
+	options := newPutOptions(opts)
+
+	body, contentType, err := encodeContent(keyName, value)
+	if err != nil {
+		return nil, err
+	}
+	if options.ContentType != "" {
+		contentType = options.ContentType
+	}
+
+	if encryption := ctx.encryptionSettings(); encryption != nil {
+		encrypted, metadata, err := ctx.encrypt(encryption, bucketName, keyName, body)
+		if err != nil {
+			return nil, err
+		}
+		body = encrypted
+		options.Metadata = mergeMetadata(options.Metadata, metadata)
+	}
+
+	var sequence int64
+	if options.Signer != nil {
+		sequence = newSignatureSequence()
+		signatureMetadata := map[string]string{metadataSignatureSequence: strconv.FormatInt(sequence, 10)}
+
+		// No metadado a versão ainda não é conhecida; a assinatura irmã é gerada após a gravação
+		if options.SignatureLocation == SignatureMetadata {
+			signature, err := signPayload(options.Signer, signaturePayload(bucketName, keyName, "", sequence, body))
+			if err != nil {
+				return nil, err
+			}
+			signatureMetadata[metadataSignature] = signature
+		}
+		options.Metadata = mergeMetadata(options.Metadata, signatureMetadata)
+	}
+
+	input := &s3bucket.PutObjectInput{
+		Bucket:      aws.String(bucketName),
+		Key:         aws.String(keyName),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String(contentType),
+	}
+	applyPutOptions(input, options)
+
+	result, err := ctx.svc.PutObject(input)
+	if err != nil {
+		return nil, fmt.Errorf("error when writing S3 object: %w", err)
+	}
+
+	if options.Signer != nil && options.SignatureLocation == SignatureSibling {
+		versionID := aws.StringValue(result.VersionId)
+		signature, err := signPayload(options.Signer, signaturePayload(bucketName, keyName, versionID, sequence, body))
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = ctx.svc.PutObject(&s3bucket.PutObjectInput{
+			Bucket:      aws.String(bucketName),
+			Key:         aws.String(SignatureKey(keyName, versionID)),
+			Body:        bytes.NewReader([]byte(signature)),
+			ContentType: aws.String("text/plain"),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error when writing S3 object signature: %w", err)
+		}
+	}
+
+	return &PutResult{
+		ETag:      aws.StringValue(result.ETag),
+		VersionID: aws.StringValue(result.VersionId),
+	}, nil
+}
+
+// applyPutOptions transfere criptografia, metadados e tags para a requisição de gravação
+func applyPutOptions(input *s3bucket.PutObjectInput, options *PutOptions) {
+	if options.SSEKMS {
+		input.ServerSideEncryption = aws.String(s3bucket.ServerSideEncryptionAwsKms)
+		if options.KMSKeyID != "" {
+			input.SSEKMSKeyId = aws.String(options.KMSKeyID)
+		}
+	}
+	if len(options.Metadata) > 0 {
+		input.Metadata = aws.StringMap(options.Metadata)
+	}
+	if len(options.Tags) > 0 {
+		input.Tagging = aws.String(encodeTags(options.Tags))
+	}
+}
+
+func mergeMetadata(metadata, extra map[string]string) map[string]string {
+	merged := make(map[string]string, len(metadata)+len(extra))
+	for key, value := range metadata {
+		merged[key] = value
+	}
+	for key, value := range extra {
+		merged[key] = value
+	}
+	return merged
+}
+
+// encodeTags converte as tags para o formato de query string exigido pelo S3
+func encodeTags(tags map[string]string) string {
+	values := url.Values{}
+	for key, value := range tags {
+		values.Set(key, value)
+	}
+	return values.Encode()
+}
+
+// encodeContent converte o valor para o formato indicado pela extensão do arquivo
+func encodeContent(keyName string, value interface{}) ([]byte, string, error) {
+	switch formatFromExtension(keyName) {
+	case FormatJSON:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, "", fmt.Errorf("error when encoding JSON: %w", err)
+		}
+		return data, "application/json", nil
+
+	case FormatYAML:
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return nil, "", fmt.Errorf("error when encoding yaml: %w", err)
+		}
+		return data, "application/yaml", nil
+
+	case FormatCSV:
+		records, err := toCSVRecords(value)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var buf bytes.Buffer
+		writer := csv.NewWriter(&buf)
+		if err := writer.WriteAll(records); err != nil {
+			return nil, "", fmt.Errorf("error when encoding CSV: %w", err)
+		}
+		return buf.Bytes(), "text/csv", nil
+
+	default:
+		switch v := value.(type) {
+		case string:
+			return []byte(v), "text/plain", nil
+		case []byte:
+			return v, "application/octet-stream", nil
+		default:
+			return nil, "", fmt.Errorf("unsupported value type for %s: %T", keyName, value)
+		}
+	}
+}
+
+// toCSVRecords converte linhas cruas ou mapas em registros CSV, gerando o cabeçalho a partir das chaves
+func toCSVRecords(value interface{}) ([][]string, error) {
+	switch v := value.(type) {
+	case [][]string:
+		return v, nil
+
+	case []map[string]string:
+		rows := make([]map[string]interface{}, len(v))
+		for i, row := range v {
+			rows[i] = make(map[string]interface{}, len(row))
+			for key, cell := range row {
+				rows[i][key] = cell
+			}
+		}
+		return mapsToCSVRecords(rows), nil
+
+	case []map[string]interface{}:
+		return mapsToCSVRecords(v), nil
+
+	case []interface{}:
+		rows := make([]map[string]interface{}, len(v))
+		for i, item := range v {
+			row, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("unsupported CSV row type at index %d: %T", i, item)
+			}
+			rows[i] = row
+		}
+		return mapsToCSVRecords(rows), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported value type for CSV: %T", value)
+	}
+}
+
+func mapsToCSVRecords(rows []map[string]interface{}) [][]string {
+	seen := make(map[string]bool)
+	headers := make([]string, 0)
+	for _, row := range rows {
+		for key := range row {
+			if !seen[key] {
+				seen[key] = true
+				headers = append(headers, key)
+			}
+		}
+	}
+	sort.Strings(headers)
+
+	records := make([][]string, 0, len(rows)+1)
+	records = append(records, headers)
+	for _, row := range rows {
+		record := make([]string, len(headers))
+		for i, header := range headers {
+			if cell, ok := row[header]; ok && cell != nil {
+				record[i] = fmt.Sprint(cell)
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
@@ -0,0 +1,272 @@
+package sns
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	snsTopic "github.com/aws/aws-sdk-go/service/sns"
+	"github.com/raywall/cloud-easy-connector/internal/aws/batch"
+)
+
+type SNSResource interface {
+	Publish(input *snsTopic.PublishInput) (*snsTopic.PublishOutput, error)
+	PublishBatch(input *snsTopic.PublishBatchInput) (*snsTopic.PublishBatchOutput, error)
+}
+
+// SNSCloudContext implementa CloudContext para SNS
+type SNSCloudContext struct {
+	svc SNSResource
+}
+
+func NewSNSContext(sess *session.Session) *SNSCloudContext {
+	return &SNSCloudContext{
+		svc: snsTopic.New(sess),
+	}
+}
+
+// Message é uma mensagem a ser publicada no tópico
+type Message struct {
+	// ID identifica a mensagem no resultado de PublishBatch; o padrão é a posição da mensagem no lote,
+	// e IDs repetidos na mesma chamada são recusados
+	ID string
+
+	Subject string
+
+	// Payload é publicado como texto quando for string ou []byte e convertido para JSON nos demais casos
+	Payload interface{}
+
+	// Attributes aceita string, números, bool, []byte (Binary) e listas (String.Array)
+	Attributes map[string]interface{}
+
+	// Protocols define uma mensagem por protocolo (email, sqs, lambda, http...), convertidas da mesma
+	// forma que Payload; quando não há "default", Payload é usado como mensagem padrão
+	Protocols map[string]interface{}
+
+	// GroupID e DeduplicationID são usados em tópicos FIFO
+	GroupID         string
+	DeduplicationID string
+}
+
+// BatchFailure descreve uma mensagem que o SNS não aceitou em PublishBatch
+type BatchFailure = batch.Failure
+
+// BatchResult relaciona o ID de cada mensagem publicada ao MessageId atribuído pelo SNS e lista as falhas
+type BatchResult = batch.Result
+
+// preparedMessage é a mensagem já convertida para os campos da API do SNS
+type preparedMessage struct {
+	body       string
+	structure  string
+	attributes map[string]*snsTopic.MessageAttributeValue
+	size       int
+}
+
+// Publish publica uma única mensagem de até 256 KB e retorna o MessageId atribuído pelo SNS
+func (ctx *SNSCloudContext) Publish(topicARN string, message Message) (string, error) {
+	prepared, err := prepareMessage(message)
+	if err != nil {
+		return "", err
+	}
+	if prepared.size > batch.MaxBytes {
+		return "", fmt.Errorf("message has %d bytes and exceeds the SNS limit of %d bytes", prepared.size, batch.MaxBytes)
+	}
+
+	input := &snsTopic.PublishInput{
+		TopicArn:          aws.String(topicARN),
+		Message:           aws.String(prepared.body),
+		MessageAttributes: prepared.attributes,
+	}
+	if prepared.structure != "" {
+		input.MessageStructure = aws.String(prepared.structure)
+	}
+	if message.Subject != "" {
+		input.Subject = aws.String(message.Subject)
+	}
+	if message.GroupID != "" {
+		input.MessageGroupId = aws.String(message.GroupID)
+	}
+	if message.DeduplicationID != "" {
+		input.MessageDeduplicationId = aws.String(message.DeduplicationID)
+	}
+
+	result, err := ctx.svc.Publish(input)
+	if err != nil {
+		return "", fmt.Errorf("error when publishing SNS message: %w", err)
+	}
+	return aws.StringValue(result.MessageId), nil
+}
+
+// PublishBatch publica as mensagens em lotes de até 10 entradas e 256 KB; mensagens inválidas ou
+// recusadas pelo SNS são listadas em Failed sem interromper a publicação dos demais lotes
+func (ctx *SNSCloudContext) PublishBatch(topicARN string, messages []Message) (*BatchResult, error) {
+	ids := make([]string, len(messages))
+	for i, message := range messages {
+		ids[i] = message.ID
+	}
+	ids, err := batch.ResolveIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := batch.NewResult(len(messages))
+	pending := make([]batch.Entry[*snsTopic.PublishBatchRequestEntry], 0, len(messages))
+	for i, message := range messages {
+		prepared, err := prepareMessage(message)
+		if err == nil && prepared.size > batch.MaxBytes {
+			err = fmt.Errorf("message has %d bytes and exceeds the SNS limit of %d bytes", prepared.size, batch.MaxBytes)
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, BatchFailure{
+				ID:          ids[i],
+				Code:        "InvalidMessage",
+				Message:     err.Error(),
+				SenderFault: true,
+			})
+			continue
+		}
+
+		entry := &snsTopic.PublishBatchRequestEntry{
+			Id:                aws.String(ids[i]),
+			Message:           aws.String(prepared.body),
+			MessageAttributes: prepared.attributes,
+		}
+		if prepared.structure != "" {
+			entry.MessageStructure = aws.String(prepared.structure)
+		}
+		if message.Subject != "" {
+			entry.Subject = aws.String(message.Subject)
+		}
+		if message.GroupID != "" {
+			entry.MessageGroupId = aws.String(message.GroupID)
+		}
+		if message.DeduplicationID != "" {
+			entry.MessageDeduplicationId = aws.String(message.DeduplicationID)
+		}
+		pending = append(pending, batch.Entry[*snsTopic.PublishBatchRequestEntry]{Value: entry, Size: prepared.size})
+	}
+
+	for _, entries := range batch.Split(pending) {
+		output, err := ctx.svc.PublishBatch(&snsTopic.PublishBatchInput{
+			TopicArn:                   aws.String(topicARN),
+			PublishBatchRequestEntries: entries,
+		})
+		if err != nil {
+			return result, fmt.Errorf("error when publishing SNS message batch: %w", err)
+		}
+
+		for _, entry := range output.Successful {
+			result.Successful[aws.StringValue(entry.Id)] = aws.StringValue(entry.MessageId)
+		}
+		for _, entry := range output.Failed {
+			result.Failed = append(result.Failed, BatchFailure{
+				ID:          aws.StringValue(entry.Id),
+				Code:        aws.StringValue(entry.Code),
+				Message:     aws.StringValue(entry.Message),
+				SenderFault: aws.BoolValue(entry.SenderFault),
+			})
+		}
+	}
+	return result, nil
+}
+
+func prepareMessage(message Message) (*preparedMessage, error) {
+	prepared := &preparedMessage{}
+
+	if len(message.Protocols) > 0 {
+		structure := make(map[string]string, len(message.Protocols)+1)
+		for protocol, payload := range message.Protocols {
+			body, err := encodePayload(payload)
+			if err != nil {
+				return nil, fmt.Errorf("error when encoding SNS message for protocol %s: %w", protocol, err)
+			}
+			structure[protocol] = body
+		}
+		if _, ok := structure["default"]; !ok {
+			body, err := encodePayload(message.Payload)
+			if err != nil {
+				return nil, fmt.Errorf("error when encoding SNS message: %w", err)
+			}
+			structure["default"] = body
+		}
+
+		body, err := json.Marshal(structure)
+		if err != nil {
+			return nil, fmt.Errorf("error when encoding SNS message structure: %w", err)
+		}
+		prepared.body = string(body)
+		prepared.structure = "json"
+	} else {
+		body, err := encodePayload(message.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("error when encoding SNS message: %w", err)
+		}
+		prepared.body = body
+	}
+
+	attributes, size, err := messageAttributes(message.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	prepared.attributes = attributes
+	prepared.size = len(prepared.body) + size
+	return prepared, nil
+}
+
+func encodePayload(payload interface{}) (string, error) {
+	switch value := payload.(type) {
+	case string:
+		return value, nil
+	case []byte:
+		return string(value), nil
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// messageAttributes converte os atributos para os tipos do SNS e retorna o tamanho contabilizado
+func messageAttributes(attributes map[string]interface{}) (map[string]*snsTopic.MessageAttributeValue, int, error) {
+	if len(attributes) == 0 {
+		return nil, 0, nil
+	}
+
+	values := make(map[string]*snsTopic.MessageAttributeValue, len(attributes))
+	size := 0
+	for name, attribute := range attributes {
+		value := &snsTopic.MessageAttributeValue{}
+
+		switch v := attribute.(type) {
+		case string:
+			value.DataType = aws.String("String")
+			value.StringValue = aws.String(v)
+		case []byte:
+			value.DataType = aws.String("Binary")
+			value.BinaryValue = v
+		case bool:
+			value.DataType = aws.String("String")
+			value.StringValue = aws.String(strconv.FormatBool(v))
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			value.DataType = aws.String("Number")
+			value.StringValue = aws.String(fmt.Sprint(v))
+		case []string, []interface{}, []int, []int64, []float64, []bool:
+			array, err := json.Marshal(v)
+			if err != nil {
+				return nil, 0, fmt.Errorf("error when encoding SNS attribute %s: %w", name, err)
+			}
+			value.DataType = aws.String("String.Array")
+			value.StringValue = aws.String(string(array))
+		default:
+			return nil, 0, fmt.Errorf("unsupported type %T for SNS attribute %s", attribute, name)
+		}
+
+		values[name] = value
+		size += len(name) + len(aws.StringValue(value.DataType)) + len(aws.StringValue(value.StringValue)) + len(value.BinaryValue)
+	}
+	return values, size, nil
+}
@@ -0,0 +1,154 @@
+package sns
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock para SNS
+type mockSNSClient struct {
+	mock.Mock
+}
+
+func (m *mockSNSClient) Publish(input *sns.PublishInput) (*sns.PublishOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sns.PublishOutput), args.Error(1)
+}
+
+func (m *mockSNSClient) PublishBatch(input *sns.PublishBatchInput) (*sns.PublishBatchOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sns.PublishBatchOutput), args.Error(1)
+}
+
+var (
+	mockSNS *mockSNSClient
+	ctx     *SNSCloudContext
+)
+
+func loadDefaultVariables() {
+	mockSNS = new(mockSNSClient)
+	ctx = &SNSCloudContext{
+		svc: mockSNS,
+	}
+}
+
+func TestSNSCloudContext_Publish(t *testing.T) {
+	t.Run("Publish JSON payload with typed attributes on FIFO topic", func(t *testing.T) {
+		loadDefaultVariables()
+
+		var input *sns.PublishInput
+		mockSNS.On("Publish", mock.Anything).Run(func(args mock.Arguments) {
+			input = args.Get(0).(*sns.PublishInput)
+		}).Return(&sns.PublishOutput{MessageId: aws.String("m-1")}, nil)
+
+		id, err := ctx.Publish("arn:topic.fifo", Message{
+			Payload: map[string]interface{}{"order": 10},
+			Attributes: map[string]interface{}{
+				"type":    "created",
+				"amount":  12.5,
+				"regions": []string{"br", "ar"},
+				"raw":     []byte{1, 2},
+			},
+			GroupID:         "orders",
+			DeduplicationID: "order-10",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "m-1", id)
+		assert.JSONEq(t, `{"order":10}`, aws.StringValue(input.Message))
+		assert.Nil(t, input.MessageStructure)
+		assert.Equal(t, "String", aws.StringValue(input.MessageAttributes["type"].DataType))
+		assert.Equal(t, "Number", aws.StringValue(input.MessageAttributes["amount"].DataType))
+		assert.Equal(t, "12.5", aws.StringValue(input.MessageAttributes["amount"].StringValue))
+		assert.Equal(t, "String.Array", aws.StringValue(input.MessageAttributes["regions"].DataType))
+		assert.Equal(t, `["br","ar"]`, aws.StringValue(input.MessageAttributes["regions"].StringValue))
+		assert.Equal(t, []byte{1, 2}, input.MessageAttributes["raw"].BinaryValue)
+		assert.Equal(t, "orders", aws.StringValue(input.MessageGroupId))
+		assert.Equal(t, "order-10", aws.StringValue(input.MessageDeduplicationId))
+	})
+
+	t.Run("Publish per-protocol message structure", func(t *testing.T) {
+		loadDefaultVariables()
+
+		var input *sns.PublishInput
+		mockSNS.On("Publish", mock.Anything).Run(func(args mock.Arguments) {
+			input = args.Get(0).(*sns.PublishInput)
+		}).Return(&sns.PublishOutput{MessageId: aws.String("m-2")}, nil)
+
+		_, err := ctx.Publish("arn:topic", Message{
+			Subject: "Order created",
+			Payload: "order 10 created",
+			Protocols: map[string]interface{}{
+				"sqs": map[string]interface{}{"order": 10},
+			},
+		})
+		assert.NoError(t, err)
+
+		var structure map[string]string
+		assert.NoError(t, json.Unmarshal([]byte(aws.StringValue(input.Message)), &structure))
+		assert.Equal(t, "json", aws.StringValue(input.MessageStructure))
+		assert.Equal(t, "order 10 created", structure["default"])
+		assert.JSONEq(t, `{"order":10}`, structure["sqs"])
+	})
+
+	t.Run("Unsupported attribute type", func(t *testing.T) {
+		loadDefaultVariables()
+
+		_, err := ctx.Publish("arn:topic", Message{Payload: "x", Attributes: map[string]interface{}{"bad": struct{}{}}})
+
+		assert.Error(t, err)
+		mockSNS.AssertNotCalled(t, "Publish", mock.Anything)
+	})
+
+	t.Run("Reject message above the size limit", func(t *testing.T) {
+		loadDefaultVariables()
+
+		_, err := ctx.Publish("arn:topic", Message{Payload: strings.Repeat("x", 300*1024)})
+
+		assert.ErrorContains(t, err, "exceeds the SNS limit")
+		mockSNS.AssertNotCalled(t, "Publish", mock.Anything)
+	})
+}
+
+func TestSNSCloudContext_PublishBatch(t *testing.T) {
+	t.Run("Chunk entries and report failures", func(t *testing.T) {
+		loadDefaultVariables()
+
+		var batches []int
+		mockSNS.On("PublishBatch", mock.Anything).Run(func(args mock.Arguments) {
+			batches = append(batches, len(args.Get(0).(*sns.PublishBatchInput).PublishBatchRequestEntries))
+		}).Return(&sns.PublishBatchOutput{
+			Successful: []*sns.PublishBatchResultEntry{{Id: aws.String("0"), MessageId: aws.String("m-0")}},
+			Failed:     []*sns.BatchResultErrorEntry{{Id: aws.String("1"), Code: aws.String("InternalError")}},
+		}, nil)
+
+		messages := make([]Message, 0, 13)
+		for i := 0; i < 12; i++ {
+			messages = append(messages, Message{Payload: map[string]int{"n": i}})
+		}
+		messages = append(messages, Message{ID: "huge", Payload: strings.Repeat("x", 300*1024)})
+
+		result, err := ctx.PublishBatch("arn:topic", messages)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{10, 2}, batches)
+		assert.Equal(t, "m-0", result.Successful["0"])
+		assert.Len(t, result.Failed, 3)
+		assert.Equal(t, "huge", result.Failed[0].ID)
+	})
+
+	t.Run("Reject id that collides with a default id", func(t *testing.T) {
+		loadDefaultVariables()
+
+		_, err := ctx.PublishBatch("arn:topic", []Message{{Payload: "a"}, {ID: "0", Payload: "b"}})
+
+		assert.Error(t, err)
+		mockSNS.AssertNotCalled(t, "PublishBatch", mock.Anything)
+	})
+}
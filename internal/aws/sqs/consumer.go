@@ -0,0 +1,348 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	sqsQueue "github.com/aws/aws-sdk-go/service/sqs"
+)
+
+// maxWaitTime é a maior duração de long polling aceita pelo ReceiveMessage
+const maxWaitTime = 20 * time.Second
+
+// receiveErrorDelay é a espera antes de uma nova tentativa quando o ReceiveMessage falha
+var receiveErrorDelay = time.Second
+
+// ReceivedMessage é uma mensagem recebida da fila e entregue ao Handler
+type ReceivedMessage struct {
+	ID            string
+	ReceiptHandle string
+	Body          string
+	Attributes    map[string]string
+
+	// ReceiveCount indica quantas vezes a mensagem já foi recebida, incluindo esta
+	ReceiveCount int64
+}
+
+// Handler processa uma mensagem; a mensagem é removida da fila somente quando o Handler não retorna erro.
+// Um panic no Handler é recuperado e tratado como erro
+type Handler func(ctx context.Context, message *ReceivedMessage) error
+
+// ConsumerOption configura a execução de Consume
+type ConsumerOption func(*ConsumerOptions)
+
+// ConsumerOptions reúne as configurações do consumidor
+type ConsumerOptions struct {
+	// Concurrency limita as mensagens processadas simultaneamente; o padrão é 10
+	Concurrency int
+
+	// MaxMessages limita as mensagens por ReceiveMessage, entre 1 e 10; o padrão é 10. Cada chamada
+	// pede no máximo a quantidade de slots livres de Concurrency
+	MaxMessages int64
+
+	// WaitTime é a duração do long polling, até 20 segundos; o padrão é 20 segundos
+	WaitTime time.Duration
+
+	// VisibilityTimeout é renovado enquanto o Handler estiver em execução; o padrão é 30 segundos
+	VisibilityTimeout time.Duration
+
+	// DeleteInterval é o tempo máximo que uma mensagem processada aguarda para ser removida em lote;
+	// o padrão é 1 segundo
+	DeleteInterval time.Duration
+
+	// OnError recebe os erros de recebimento, processamento, extensão de visibilidade e remoção;
+	// pode ser chamado simultaneamente por vários handlers
+	OnError func(err error)
+}
+
+// WithConcurrency limita as mensagens processadas simultaneamente
+func WithConcurrency(concurrency int) ConsumerOption {
+	return func(o *ConsumerOptions) {
+		o.Concurrency = concurrency
+	}
+}
+
+// WithMaxMessages limita as mensagens solicitadas por ReceiveMessage
+func WithMaxMessages(maxMessages int64) ConsumerOption {
+	return func(o *ConsumerOptions) {
+		o.MaxMessages = maxMessages
+	}
+}
+
+// WithWaitTime define a duração do long polling
+func WithWaitTime(waitTime time.Duration) ConsumerOption {
+	return func(o *ConsumerOptions) {
+		o.WaitTime = waitTime
+	}
+}
+
+// WithVisibilityTimeout define o tempo de visibilidade renovado enquanto o Handler estiver em execução
+func WithVisibilityTimeout(timeout time.Duration) ConsumerOption {
+	return func(o *ConsumerOptions) {
+		o.VisibilityTimeout = timeout
+	}
+}
+
+// WithDeleteInterval define o tempo máximo de espera para a remoção em lote das mensagens processadas
+func WithDeleteInterval(interval time.Duration) ConsumerOption {
+	return func(o *ConsumerOptions) {
+		o.DeleteInterval = interval
+	}
+}
+
+// WithErrorHandler recebe os erros que não interrompem o consumidor
+func WithErrorHandler(onError func(err error)) ConsumerOption {
+	return func(o *ConsumerOptions) {
+		o.OnError = onError
+	}
+}
+
+func newConsumerOptions(opts []ConsumerOption) *ConsumerOptions {
+	options := &ConsumerOptions{
+		Concurrency:       10,
+		MaxMessages:       maxBatchEntries,
+		WaitTime:          20 * time.Second,
+		VisibilityTimeout: 30 * time.Second,
+		DeleteInterval:    time.Second,
+		OnError:           func(error) {},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
+
+// Consume recebe mensagens da fila com long polling e as entrega ao handler com concorrência limitada.
+// Enquanto o handler executa, a visibilidade da mensagem é renovada; mensagens processadas com sucesso
+// são removidas em lotes. Quando ctx é cancelado, o consumidor deixa de receber mensagens, aguarda os
+// handlers em execução, remove as mensagens já processadas e retorna ctx.Err()
+func (ctx *SQSCloudContext) Consume(runCtx context.Context, queueURL string, handler Handler, opts ...ConsumerOption) error {
+	options := newConsumerOptions(opts)
+	if options.Concurrency <= 0 {
+		return fmt.Errorf("invalid SQS consumer concurrency: %d", options.Concurrency)
+	}
+	if options.MaxMessages < 1 || options.MaxMessages > maxBatchEntries {
+		return fmt.Errorf("invalid SQS consumer max messages: %d", options.MaxMessages)
+	}
+	if options.WaitTime < 0 || options.WaitTime > maxWaitTime {
+		return fmt.Errorf("invalid SQS consumer wait time: %s", options.WaitTime)
+	}
+	if options.VisibilityTimeout < time.Second || options.DeleteInterval <= 0 {
+		return errors.New("invalid SQS consumer visibility timeout or delete interval")
+	}
+
+	deletes := make(chan string)
+	deleterDone := make(chan struct{})
+	go func() {
+		defer close(deleterDone)
+		ctx.deleteLoop(queueURL, deletes, options)
+	}()
+
+	// os handlers não são cancelados junto com runCtx, permitindo que terminem durante o desligamento
+	handlerCtx := context.WithoutCancel(runCtx)
+	slots := make(chan struct{}, options.Concurrency)
+	var running sync.WaitGroup
+
+	for runCtx.Err() == nil {
+		// Só são solicitadas mensagens para os slots livres: uma mensagem recebida começa a ser
+		// processada, e a ter a visibilidade renovada, imediatamente, sem esperar na fila local
+		reserved := reserveSlots(runCtx, slots, options.MaxMessages)
+		if reserved == 0 {
+			break
+		}
+
+		messages, err := ctx.receive(runCtx, queueURL, reserved, options)
+		for i := int64(len(messages)); i < reserved; i++ {
+			<-slots
+		}
+		if err != nil {
+			if runCtx.Err() != nil {
+				break
+			}
+			options.OnError(err)
+			select {
+			case <-runCtx.Done():
+			case <-time.After(receiveErrorDelay):
+			}
+			continue
+		}
+
+		for i, message := range messages {
+			if int64(i) >= reserved {
+				slots <- struct{}{}
+			}
+			running.Add(1)
+			go func(message *ReceivedMessage) {
+				defer func() {
+					<-slots
+					running.Done()
+				}()
+				if ctx.process(handlerCtx, queueURL, message, handler, options) {
+					deletes <- message.ReceiptHandle
+				}
+			}(message)
+		}
+	}
+
+	running.Wait()
+	close(deletes)
+	<-deleterDone
+	return runCtx.Err()
+}
+
+// reserveSlots aguarda ao menos um slot livre e reserva os demais disponíveis, até maxMessages;
+// retorna zero quando runCtx é cancelado
+func reserveSlots(runCtx context.Context, slots chan struct{}, maxMessages int64) int64 {
+	select {
+	case slots <- struct{}{}:
+	case <-runCtx.Done():
+		return 0
+	}
+
+	reserved := int64(1)
+	for reserved < maxMessages {
+		select {
+		case slots <- struct{}{}:
+			reserved++
+		default:
+			return reserved
+		}
+	}
+	return reserved
+}
+
+func (ctx *SQSCloudContext) receive(runCtx context.Context, queueURL string, maxMessages int64, options *ConsumerOptions) ([]*ReceivedMessage, error) {
+	result, err := ctx.svc.ReceiveMessageWithContext(runCtx, &sqsQueue.ReceiveMessageInput{
+		QueueUrl:              aws.String(queueURL),
+		MaxNumberOfMessages:   aws.Int64(maxMessages),
+		WaitTimeSeconds:       aws.Int64(int64(options.WaitTime / time.Second)),
+		VisibilityTimeout:     aws.Int64(int64(options.VisibilityTimeout / time.Second)),
+		MessageAttributeNames: aws.StringSlice([]string{"All"}),
+		AttributeNames:        aws.StringSlice([]string{sqsQueue.MessageSystemAttributeNameApproximateReceiveCount}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when receiving SQS messages: %w", err)
+	}
+
+	messages := make([]*ReceivedMessage, 0, len(result.Messages))
+	for _, message := range result.Messages {
+		received := &ReceivedMessage{
+			ID:            aws.StringValue(message.MessageId),
+			ReceiptHandle: aws.StringValue(message.ReceiptHandle),
+			Body:          aws.StringValue(message.Body),
+			Attributes:    make(map[string]string, len(message.MessageAttributes)),
+		}
+		for name, value := range message.MessageAttributes {
+			received.Attributes[name] = aws.StringValue(value.StringValue)
+		}
+		received.ReceiveCount, _ = strconv.ParseInt(aws.StringValue(message.Attributes[sqsQueue.MessageSystemAttributeNameApproximateReceiveCount]), 10, 64)
+		messages = append(messages, received)
+	}
+	return messages, nil
+}
+
+// process executa o handler renovando a visibilidade da mensagem na metade de cada período
+func (ctx *SQSCloudContext) process(handlerCtx context.Context, queueURL string, message *ReceivedMessage, handler Handler, options *ConsumerOptions) bool {
+	done := make(chan struct{})
+	var extending sync.WaitGroup
+	extending.Add(1)
+	go func() {
+		defer extending.Done()
+		ticker := time.NewTicker(options.VisibilityTimeout / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				_, err := ctx.svc.ChangeMessageVisibility(&sqsQueue.ChangeMessageVisibilityInput{
+					QueueUrl:          aws.String(queueURL),
+					ReceiptHandle:     aws.String(message.ReceiptHandle),
+					VisibilityTimeout: aws.Int64(int64(options.VisibilityTimeout / time.Second)),
+				})
+				if err != nil {
+					options.OnError(fmt.Errorf("error when extending visibility of SQS message %s: %w", message.ID, err))
+				}
+			}
+		}
+	}()
+
+	err := runHandler(handlerCtx, message, handler)
+	close(done)
+	extending.Wait()
+
+	if err != nil {
+		options.OnError(fmt.Errorf("error when handling SQS message %s: %w", message.ID, err))
+		return false
+	}
+	return true
+}
+
+// runHandler converte um panic do handler em erro, mantendo a mensagem na fila e o consumidor em execução
+func runHandler(handlerCtx context.Context, message *ReceivedMessage, handler Handler) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("handler panic: %v", recovered)
+		}
+	}()
+	return handler(handlerCtx, message)
+}
+
+// deleteLoop remove as mensagens processadas em lotes de até 10, ou a cada DeleteInterval
+func (ctx *SQSCloudContext) deleteLoop(queueURL string, deletes <-chan string, options *ConsumerOptions) {
+	var handles []string
+	ticker := time.NewTicker(options.DeleteInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case handle, ok := <-deletes:
+			if !ok {
+				ctx.deleteBatch(queueURL, handles, options)
+				return
+			}
+			handles = append(handles, handle)
+			if len(handles) == maxBatchEntries {
+				ctx.deleteBatch(queueURL, handles, options)
+				handles = nil
+			}
+		case <-ticker.C:
+			ctx.deleteBatch(queueURL, handles, options)
+			handles = nil
+		}
+	}
+}
+
+func (ctx *SQSCloudContext) deleteBatch(queueURL string, handles []string, options *ConsumerOptions) {
+	if len(handles) == 0 {
+		return
+	}
+
+	entries := make([]*sqsQueue.DeleteMessageBatchRequestEntry, 0, len(handles))
+	for i, handle := range handles {
+		entries = append(entries, &sqsQueue.DeleteMessageBatchRequestEntry{
+			Id:            aws.String(strconv.Itoa(i)),
+			ReceiptHandle: aws.String(handle),
+		})
+	}
+
+	result, err := ctx.svc.DeleteMessageBatch(&sqsQueue.DeleteMessageBatchInput{
+		QueueUrl: aws.String(queueURL),
+		Entries:  entries,
+	})
+	if err != nil {
+		options.OnError(fmt.Errorf("error when deleting SQS messages: %w", err))
+		return
+	}
+	for _, failed := range result.Failed {
+		options.OnError(fmt.Errorf("error when deleting SQS message %s: %s", aws.StringValue(failed.Id), aws.StringValue(failed.Message)))
+	}
+}
@@ -0,0 +1,132 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSQSCloudContext_Consume(t *testing.T) {
+	t.Run("Process, extend visibility and delete in batch", func(t *testing.T) {
+		loadDefaultVariables()
+
+		runCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Com concorrência 2 o consumidor nunca pede mais mensagens que os slots livres
+		var requested []int64
+		mockSQS.On("ReceiveMessageWithContext", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			requested = append(requested, aws.Int64Value(args.Get(1).(*sqs.ReceiveMessageInput).MaxNumberOfMessages))
+		}).Return(&sqs.ReceiveMessageOutput{
+			Messages: []*sqs.Message{
+				{MessageId: aws.String("ok"), ReceiptHandle: aws.String("h-ok"), Body: aws.String("1"),
+					Attributes: map[string]*string{sqs.MessageSystemAttributeNameApproximateReceiveCount: aws.String("2")}},
+				{MessageId: aws.String("slow"), ReceiptHandle: aws.String("h-slow"), Body: aws.String("2")},
+			},
+		}, nil).Once()
+		mockSQS.On("ReceiveMessageWithContext", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			requested = append(requested, aws.Int64Value(args.Get(1).(*sqs.ReceiveMessageInput).MaxNumberOfMessages))
+		}).Return(&sqs.ReceiveMessageOutput{
+			Messages: []*sqs.Message{
+				{MessageId: aws.String("fail"), ReceiptHandle: aws.String("h-fail"), Body: aws.String("3")},
+			},
+		}, nil).Once()
+		mockSQS.On("ReceiveMessageWithContext", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).Return((*sqs.ReceiveMessageOutput)(nil), context.Canceled)
+		mockSQS.On("ChangeMessageVisibility", mock.MatchedBy(func(input *sqs.ChangeMessageVisibilityInput) bool {
+			return aws.StringValue(input.ReceiptHandle) == "h-slow"
+		})).Return(&sqs.ChangeMessageVisibilityOutput{}, nil)
+
+		var mutex sync.Mutex
+		var deleted []string
+		mockSQS.On("DeleteMessageBatch", mock.Anything).Run(func(args mock.Arguments) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, entry := range args.Get(0).(*sqs.DeleteMessageBatchInput).Entries {
+				deleted = append(deleted, aws.StringValue(entry.ReceiptHandle))
+			}
+		}).Return(&sqs.DeleteMessageBatchOutput{}, nil)
+
+		var handled sync.WaitGroup
+		handled.Add(3)
+		var receiveCount int64
+		var errs []error
+		go func() {
+			handled.Wait()
+			cancel()
+		}()
+
+		err := ctx.Consume(runCtx, "https://queue", func(_ context.Context, message *ReceivedMessage) error {
+			defer handled.Done()
+			switch message.ID {
+			case "ok":
+				receiveCount = message.ReceiveCount
+			case "slow":
+				time.Sleep(700 * time.Millisecond)
+			case "fail":
+				return errors.New("boom")
+			}
+			return nil
+		}, WithVisibilityTimeout(time.Second), WithConcurrency(2), WithErrorHandler(func(err error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			errs = append(errs, err)
+		}))
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int64(2), receiveCount)
+		assert.ElementsMatch(t, []string{"h-ok", "h-slow"}, deleted)
+		assert.Len(t, errs, 1)
+		assert.Equal(t, int64(2), requested[0])
+		for _, maxMessages := range requested {
+			assert.LessOrEqual(t, maxMessages, int64(2))
+		}
+		mockSQS.AssertCalled(t, "ChangeMessageVisibility", mock.Anything)
+	})
+
+	t.Run("Recover handler panics without deleting the message", func(t *testing.T) {
+		loadDefaultVariables()
+
+		runCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mockSQS.On("ReceiveMessageWithContext", mock.Anything, mock.Anything).Return(&sqs.ReceiveMessageOutput{
+			Messages: []*sqs.Message{{MessageId: aws.String("bad"), ReceiptHandle: aws.String("h-bad"), Body: aws.String("1")}},
+		}, nil).Once()
+		mockSQS.On("ReceiveMessageWithContext", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).Return((*sqs.ReceiveMessageOutput)(nil), context.Canceled)
+
+		var errs []error
+		err := ctx.Consume(runCtx, "https://queue", func(context.Context, *ReceivedMessage) error {
+			panic("nil map")
+		}, WithErrorHandler(func(err error) {
+			errs = append(errs, err)
+			cancel()
+		}))
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "nil map")
+		mockSQS.AssertNotCalled(t, "DeleteMessageBatch", mock.Anything)
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		loadDefaultVariables()
+
+		err := ctx.Consume(context.Background(), "https://queue", nil, WithMaxMessages(11))
+		assert.Error(t, err)
+
+		err = ctx.Consume(context.Background(), "https://queue", nil, WithWaitTime(30*time.Second))
+		assert.Error(t, err)
+		mockSQS.AssertNotCalled(t, "ReceiveMessageWithContext", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,171 @@
+package sqs
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	sqsQueue "github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/raywall/cloud-easy-connector/internal/aws/batch"
+)
+
+// maxBatchEntries é o limite de mensagens aceito por ReceiveMessage e DeleteMessageBatch
+const maxBatchEntries = batch.MaxEntries
+
+type SQSResource interface {
+	SendMessage(input *sqsQueue.SendMessageInput) (*sqsQueue.SendMessageOutput, error)
+	SendMessageBatch(input *sqsQueue.SendMessageBatchInput) (*sqsQueue.SendMessageBatchOutput, error)
+	ReceiveMessageWithContext(ctx aws.Context, input *sqsQueue.ReceiveMessageInput, opts ...request.Option) (*sqsQueue.ReceiveMessageOutput, error)
+	ChangeMessageVisibility(input *sqsQueue.ChangeMessageVisibilityInput) (*sqsQueue.ChangeMessageVisibilityOutput, error)
+	DeleteMessageBatch(input *sqsQueue.DeleteMessageBatchInput) (*sqsQueue.DeleteMessageBatchOutput, error)
+}
+
+// SQSCloudContext implementa CloudContext para SQS
+type SQSCloudContext struct {
+	svc SQSResource
+}
+
+func NewSQSContext(sess *session.Session) *SQSCloudContext {
+	return &SQSCloudContext{
+		svc: sqsQueue.New(sess),
+	}
+}
+
+// Message é uma mensagem a ser enviada para a fila
+type Message struct {
+	// ID identifica a mensagem no resultado de SendBatch; o padrão é a posição da mensagem no lote,
+	// e IDs repetidos na mesma chamada são recusados
+	ID string
+
+	Body       string
+	Attributes map[string]string
+
+	// DelaySeconds adia a entrega da mensagem; não é suportado em filas FIFO
+	DelaySeconds int64
+
+	// GroupID e DeduplicationID são usados em filas FIFO
+	GroupID         string
+	DeduplicationID string
+}
+
+// BatchFailure descreve uma mensagem que o SQS não aceitou em SendBatch
+type BatchFailure = batch.Failure
+
+// BatchResult relaciona o ID de cada mensagem enviada ao MessageId atribuído pelo SQS e lista as falhas
+type BatchResult = batch.Result
+
+// Send envia uma única mensagem e retorna o MessageId atribuído pelo SQS
+func (ctx *SQSCloudContext) Send(queueURL string, message Message) (string, error) {
+	input := &sqsQueue.SendMessageInput{
+		QueueUrl:          aws.String(queueURL),
+		MessageBody:       aws.String(message.Body),
+		MessageAttributes: messageAttributes(message.Attributes),
+	}
+	if message.DelaySeconds > 0 {
+		input.DelaySeconds = aws.Int64(message.DelaySeconds)
+	}
+	if message.GroupID != "" {
+		input.MessageGroupId = aws.String(message.GroupID)
+	}
+	if message.DeduplicationID != "" {
+		input.MessageDeduplicationId = aws.String(message.DeduplicationID)
+	}
+
+	result, err := ctx.svc.SendMessage(input)
+	if err != nil {
+		return "", fmt.Errorf("error when sending SQS message: %w", err)
+	}
+	return aws.StringValue(result.MessageId), nil
+}
+
+// SendBatch envia as mensagens em lotes de até 10 entradas e 256 KB; mensagens recusadas pelo SQS
+// são listadas em Failed sem interromper o envio dos demais lotes
+func (ctx *SQSCloudContext) SendBatch(queueURL string, messages []Message) (*BatchResult, error) {
+	ids := make([]string, len(messages))
+	for i, message := range messages {
+		ids[i] = message.ID
+	}
+	ids, err := batch.ResolveIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := batch.NewResult(len(messages))
+	pending := make([]batch.Entry[*sqsQueue.SendMessageBatchRequestEntry], 0, len(messages))
+	for i, message := range messages {
+		size := messageSize(message)
+		if size > batch.MaxBytes {
+			result.Failed = append(result.Failed, BatchFailure{
+				ID:          ids[i],
+				Code:        "MessageTooLarge",
+				Message:     fmt.Sprintf("message has %d bytes and exceeds the SQS limit of %d bytes", size, batch.MaxBytes),
+				SenderFault: true,
+			})
+			continue
+		}
+
+		entry := &sqsQueue.SendMessageBatchRequestEntry{
+			Id:                aws.String(ids[i]),
+			MessageBody:       aws.String(message.Body),
+			MessageAttributes: messageAttributes(message.Attributes),
+		}
+		if message.DelaySeconds > 0 {
+			entry.DelaySeconds = aws.Int64(message.DelaySeconds)
+		}
+		if message.GroupID != "" {
+			entry.MessageGroupId = aws.String(message.GroupID)
+		}
+		if message.DeduplicationID != "" {
+			entry.MessageDeduplicationId = aws.String(message.DeduplicationID)
+		}
+		pending = append(pending, batch.Entry[*sqsQueue.SendMessageBatchRequestEntry]{Value: entry, Size: size})
+	}
+
+	for _, entries := range batch.Split(pending) {
+		output, err := ctx.svc.SendMessageBatch(&sqsQueue.SendMessageBatchInput{
+			QueueUrl: aws.String(queueURL),
+			Entries:  entries,
+		})
+		if err != nil {
+			return result, fmt.Errorf("error when sending SQS message batch: %w", err)
+		}
+
+		for _, entry := range output.Successful {
+			result.Successful[aws.StringValue(entry.Id)] = aws.StringValue(entry.MessageId)
+		}
+		for _, entry := range output.Failed {
+			result.Failed = append(result.Failed, BatchFailure{
+				ID:          aws.StringValue(entry.Id),
+				Code:        aws.StringValue(entry.Code),
+				Message:     aws.StringValue(entry.Message),
+				SenderFault: aws.BoolValue(entry.SenderFault),
+			})
+		}
+	}
+	return result, nil
+}
+
+func messageAttributes(attributes map[string]string) map[string]*sqsQueue.MessageAttributeValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	values := make(map[string]*sqsQueue.MessageAttributeValue, len(attributes))
+	for name, value := range attributes {
+		values[name] = &sqsQueue.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(value),
+		}
+	}
+	return values
+}
+
+// messageSize calcula o tamanho contabilizado pelo SQS: corpo, nomes, tipos e valores dos atributos
+func messageSize(message Message) int {
+	size := len(message.Body)
+	for name, value := range message.Attributes {
+		size += len(name) + len("String") + len(value)
+	}
+	return size
+}
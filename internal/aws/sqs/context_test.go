@@ -0,0 +1,126 @@
+package sqs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock para SQS
+type mockSQSClient struct {
+	mock.Mock
+}
+
+func (m *mockSQSClient) SendMessage(input *sqs.SendMessageInput) (*sqs.SendMessageOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sqs.SendMessageOutput), args.Error(1)
+}
+
+func (m *mockSQSClient) SendMessageBatch(input *sqs.SendMessageBatchInput) (*sqs.SendMessageBatchOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sqs.SendMessageBatchOutput), args.Error(1)
+}
+
+func (m *mockSQSClient) ReceiveMessageWithContext(ctx aws.Context, input *sqs.ReceiveMessageInput, opts ...request.Option) (*sqs.ReceiveMessageOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*sqs.ReceiveMessageOutput), args.Error(1)
+}
+
+func (m *mockSQSClient) ChangeMessageVisibility(input *sqs.ChangeMessageVisibilityInput) (*sqs.ChangeMessageVisibilityOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sqs.ChangeMessageVisibilityOutput), args.Error(1)
+}
+
+func (m *mockSQSClient) DeleteMessageBatch(input *sqs.DeleteMessageBatchInput) (*sqs.DeleteMessageBatchOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sqs.DeleteMessageBatchOutput), args.Error(1)
+}
+
+var (
+	mockSQS *mockSQSClient
+	ctx     *SQSCloudContext
+)
+
+func loadDefaultVariables() {
+	mockSQS = new(mockSQSClient)
+	ctx = &SQSCloudContext{
+		svc: mockSQS,
+	}
+}
+
+func TestSQSCloudContext_Send(t *testing.T) {
+	t.Run("Send FIFO message with attributes", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockSQS.On("SendMessage", mock.MatchedBy(func(input *sqs.SendMessageInput) bool {
+			return aws.StringValue(input.MessageGroupId) == "orders" &&
+				aws.StringValue(input.MessageAttributes["type"].StringValue) == "created"
+		})).Return(&sqs.SendMessageOutput{MessageId: aws.String("m-1")}, nil)
+
+		id, err := ctx.Send("https://queue", Message{
+			Body:       `{"id":1}`,
+			Attributes: map[string]string{"type": "created"},
+			GroupID:    "orders",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "m-1", id)
+	})
+}
+
+func TestSQSCloudContext_SendBatch(t *testing.T) {
+	t.Run("Chunk by entry count and size", func(t *testing.T) {
+		loadDefaultVariables()
+
+		var batches []int
+		mockSQS.On("SendMessageBatch", mock.Anything).Run(func(args mock.Arguments) {
+			batches = append(batches, len(args.Get(0).(*sqs.SendMessageBatchInput).Entries))
+		}).Return(&sqs.SendMessageBatchOutput{
+			Successful: []*sqs.SendMessageBatchResultEntry{{Id: aws.String("0"), MessageId: aws.String("m-0")}},
+		}, nil)
+
+		messages := make([]Message, 0, 15)
+		for i := 0; i < 12; i++ {
+			messages = append(messages, Message{Body: "small"})
+		}
+		// duas mensagens de 150 KB não cabem no mesmo lote
+		messages = append(messages, Message{Body: strings.Repeat("a", 150*1024)}, Message{Body: strings.Repeat("b", 150*1024)})
+		// uma mensagem acima do limite não é enviada
+		messages = append(messages, Message{ID: "huge", Body: strings.Repeat("c", 300*1024)})
+
+		result, err := ctx.SendBatch("https://queue", messages)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{10, 3, 1}, batches)
+		assert.Equal(t, "m-0", result.Successful["0"])
+		assert.Len(t, result.Failed, 1)
+		assert.Equal(t, "huge", result.Failed[0].ID)
+	})
+
+	t.Run("Report failed entries", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockSQS.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{
+			Failed: []*sqs.BatchResultErrorEntry{{Id: aws.String("a"), Code: aws.String("InvalidMessageContents"), SenderFault: aws.Bool(true)}},
+		}, nil)
+
+		result, err := ctx.SendBatch("https://queue", []Message{{ID: "a", Body: "x"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []BatchFailure{{ID: "a", Code: "InvalidMessageContents", SenderFault: true}}, result.Failed)
+	})
+
+	t.Run("Reject duplicated ids", func(t *testing.T) {
+		loadDefaultVariables()
+
+		_, err := ctx.SendBatch("https://queue", []Message{{Body: "x"}, {Body: "y"}, {ID: "1", Body: "z"}})
+
+		assert.Error(t, err)
+		mockSQS.AssertNotCalled(t, "SendMessageBatch", mock.Anything)
+	})
+}
@@ -0,0 +1,138 @@
+package sts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	stsService "github.com/aws/aws-sdk-go/service/sts"
+)
+
+// credentialExpiryWindow antecipa a renovação das credenciais do papel, evitando que uma requisição
+// assinada perto da expiração chegue à AWS com a credencial já expirada
+const credentialExpiryWindow = time.Minute
+
+type STSResource interface {
+	GetCallerIdentity(input *stsService.GetCallerIdentityInput) (*stsService.GetCallerIdentityOutput, error)
+}
+
+// STSCloudContext implementa CloudContext para STS
+type STSCloudContext struct {
+	svc STSResource
+}
+
+func NewSTSContext(sess *session.Session) *STSCloudContext {
+	return &STSCloudContext{
+		svc: stsService.New(sess),
+	}
+}
+
+// Identity identifica a conta e o principal associados às credenciais em uso
+type Identity struct {
+	Account string
+	Arn     string
+	UserID  string
+}
+
+// WhoAmI obtém a conta e o principal das credenciais da sessão
+func (ctx *STSCloudContext) WhoAmI() (*Identity, error) {
+	result, err := ctx.svc.GetCallerIdentity(&stsService.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, fmt.Errorf("error when obtaining caller identity: %w", err)
+	}
+	return &Identity{
+		Account: aws.StringValue(result.Account),
+		Arn:     aws.StringValue(result.Arn),
+		UserID:  aws.StringValue(result.UserId),
+	}, nil
+}
+
+// AssumeRoleOption configura a sessão criada por AssumeRole
+type AssumeRoleOption func(*AssumeRoleOptions)
+
+// AssumeRoleOptions reúne as configurações da sessão assumida
+type AssumeRoleOptions struct {
+	// SessionName identifica a sessão no CloudTrail; o padrão é gerado pelo SDK
+	SessionName string
+
+	// ExternalID é exigido pela política de confiança de papéis de contas de terceiros
+	ExternalID string
+
+	// Duration é a validade de cada credencial emitida; o padrão é 15 minutos
+	Duration time.Duration
+
+	// Policy restringe as permissões da sessão além das permissões do papel
+	Policy string
+}
+
+// WithSessionName identifica a sessão no CloudTrail
+func WithSessionName(name string) AssumeRoleOption {
+	return func(o *AssumeRoleOptions) {
+		o.SessionName = name
+	}
+}
+
+// WithExternalID informa o ExternalID exigido pela política de confiança do papel
+func WithExternalID(externalID string) AssumeRoleOption {
+	return func(o *AssumeRoleOptions) {
+		o.ExternalID = externalID
+	}
+}
+
+// WithDuration define a validade de cada credencial emitida
+func WithDuration(duration time.Duration) AssumeRoleOption {
+	return func(o *AssumeRoleOptions) {
+		o.Duration = duration
+	}
+}
+
+// WithPolicy restringe as permissões da sessão com uma política inline
+func WithPolicy(policy string) AssumeRoleOption {
+	return func(o *AssumeRoleOptions) {
+		o.Policy = policy
+	}
+}
+
+func newAssumeRoleOptions(opts []AssumeRoleOption) *AssumeRoleOptions {
+	options := &AssumeRoleOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
+
+// AssumeRoleSession cria uma cópia da sessão que assume o papel informado; as credenciais são obtidas
+// sob demanda e renovadas automaticamente um minuto antes de expirar. A primeira credencial é solicitada
+// imediatamente, para que permissões ausentes sejam informadas na criação da sessão
+func AssumeRoleSession(sess *session.Session, roleArn string, opts ...AssumeRoleOption) (*session.Session, error) {
+	options := newAssumeRoleOptions(opts)
+
+	creds := stscreds.NewCredentials(sess, roleArn, func(p *stscreds.AssumeRoleProvider) {
+		applyAssumeRoleOptions(p, options)
+	})
+	if _, err := creds.Get(); err != nil {
+		return nil, fmt.Errorf("error when assuming role %s: %w", roleArn, err)
+	}
+
+	return sess.Copy(&aws.Config{Credentials: creds}), nil
+}
+
+func applyAssumeRoleOptions(p *stscreds.AssumeRoleProvider, options *AssumeRoleOptions) {
+	p.ExpiryWindow = credentialExpiryWindow
+	if options.SessionName != "" {
+		p.RoleSessionName = options.SessionName
+	}
+	if options.ExternalID != "" {
+		p.ExternalID = aws.String(options.ExternalID)
+	}
+	if options.Duration > 0 {
+		p.Duration = options.Duration
+	}
+	if options.Policy != "" {
+		p.Policy = aws.String(options.Policy)
+	}
+}
@@ -0,0 +1,72 @@
+package sts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock para STS
+type mockSTSClient struct {
+	mock.Mock
+}
+
+func (m *mockSTSClient) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*sts.GetCallerIdentityOutput), args.Error(1)
+}
+
+var (
+	mockSTS *mockSTSClient
+	ctx     *STSCloudContext
+)
+
+func loadDefaultVariables() {
+	mockSTS = new(mockSTSClient)
+	ctx = &STSCloudContext{
+		svc: mockSTS,
+	}
+}
+
+func TestSTSCloudContext_WhoAmI(t *testing.T) {
+	t.Run("Get caller identity", func(t *testing.T) {
+		loadDefaultVariables()
+
+		mockSTS.On("GetCallerIdentity", mock.Anything).Return(&sts.GetCallerIdentityOutput{
+			Account: aws.String("123456789012"),
+			Arn:     aws.String("arn:aws:sts::123456789012:assumed-role/app/session"),
+			UserId:  aws.String("AROA:session"),
+		}, nil)
+
+		identity, err := ctx.WhoAmI()
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Identity{
+			Account: "123456789012",
+			Arn:     "arn:aws:sts::123456789012:assumed-role/app/session",
+			UserID:  "AROA:session",
+		}, identity)
+	})
+}
+
+func TestApplyAssumeRoleOptions(t *testing.T) {
+	provider := &stscreds.AssumeRoleProvider{RoleSessionName: "default", Duration: stscreds.DefaultDuration}
+
+	applyAssumeRoleOptions(provider, newAssumeRoleOptions([]AssumeRoleOption{
+		WithSessionName("tenant-acme"),
+		WithExternalID("ext-1"),
+		WithDuration(time.Hour),
+		WithPolicy(`{"Version":"2012-10-17"}`),
+	}))
+
+	assert.Equal(t, "tenant-acme", provider.RoleSessionName)
+	assert.Equal(t, "ext-1", aws.StringValue(provider.ExternalID))
+	assert.Equal(t, time.Hour, provider.Duration)
+	assert.Equal(t, `{"Version":"2012-10-17"}`, aws.StringValue(provider.Policy))
+	assert.Equal(t, time.Minute, provider.ExpiryWindow)
+}
@@ -0,0 +1,242 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DynamoDBConfigLayout descreve como as configurações estão organizadas na tabela DynamoDB.
+// Com SortKey, cada configuração é um item (PartitionKey = partição, SortKey = chave) cujo valor
+// fica em ValueAttribute; sem SortKey, cada partição é um único item e a chave é o nome do atributo
+type DynamoDBConfigLayout struct {
+	TableName    string
+	PartitionKey string
+	SortKey      string
+
+	// ValueAttribute é o atributo com o valor da configuração; o padrão é "value"
+	ValueAttribute string
+
+	// VersionAttribute, quando informado, é usado para detectar mudanças em vez de comparar o valor
+	VersionAttribute string
+}
+
+// cachedConfig guarda o último valor lido de uma configuração e sua validade
+type cachedConfig struct {
+	value     interface{}
+	version   interface{}
+	expiresAt time.Time
+}
+
+// DynamoDBConfigProvider lê configurações de uma tabela DynamoDB, mantendo-as em cache pelo ttl informado
+type DynamoDBConfigProvider struct {
+	cc     CloudContext
+	layout DynamoDBConfigLayout
+	ttl    time.Duration
+
+	mutex sync.Mutex
+	cache map[string]*cachedConfig
+}
+
+// NewDynamoDBConfigProvider cria um provedor de configurações sobre o DynamoDBContext do CloudContext;
+// ttl igual a zero desabilita o cache
+func NewDynamoDBConfigProvider(cc CloudContext, layout DynamoDBConfigLayout, ttl time.Duration) (*DynamoDBConfigProvider, error) {
+	if layout.TableName == "" || layout.PartitionKey == "" {
+		return nil, errors.New("the configuration table and partition key are required")
+	}
+	if layout.ValueAttribute == "" {
+		layout.ValueAttribute = "value"
+	}
+
+	return &DynamoDBConfigProvider{
+		cc:     cc,
+		layout: layout,
+		ttl:    ttl,
+		cache:  make(map[string]*cachedConfig),
+	}, nil
+}
+
+// GetValue obtém o valor da configuração; atributos JSON são decodificados em mapas e listas
+func (p *DynamoDBConfigProvider) GetValue(partition, key string) (interface{}, error) {
+	id := partition + "/" + key
+
+	p.mutex.Lock()
+	cached, ok := p.cache[id]
+	p.mutex.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.value, nil
+	}
+
+	value, _, err := p.Revalidate(partition, key)
+	return value, err
+}
+
+// Revalidate lê a configuração diretamente da tabela, com leitura consistente, e atualiza o cache.
+// O retorno changed indica se o valor (ou o VersionAttribute) mudou desde a leitura anterior e é
+// sempre verdadeiro na primeira leitura
+func (p *DynamoDBConfigProvider) Revalidate(partition, key string) (value interface{}, changed bool, err error) {
+	value, version, err := p.load(partition, key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	id := partition + "/" + key
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	cached, ok := p.cache[id]
+	changed = !ok || !reflect.DeepEqual(cached.version, version)
+	p.cache[id] = &cachedConfig{
+		value:     value,
+		version:   version,
+		expiresAt: time.Now().Add(p.ttl),
+	}
+	return value, changed, nil
+}
+
+// Invalidate descarta o valor em cache, forçando a leitura da tabela na próxima chamada
+func (p *DynamoDBConfigProvider) Invalidate(partition, key string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.cache, partition+"/"+key)
+}
+
+// Watch revalida a configuração a cada intervalo e chama onChange com o valor atual na primeira leitura,
+// mesmo que ele já esteja em cache, e depois a cada mudança; erros de leitura também são repassados a
+// onChange e não interrompem o acompanhamento, e a primeira leitura bem-sucedida depois de um erro
+// repassa o valor atual mesmo sem mudança. O acompanhamento termina quando ctx é cancelado
+func (p *DynamoDBConfigProvider) Watch(ctx context.Context, partition, key string, interval time.Duration, onChange func(value interface{}, err error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid configuration watch interval: %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// notify indica que o valor atual ainda não foi entregue, na primeira leitura ou depois de um erro
+	notify := true
+	for {
+		value, changed, err := p.Revalidate(partition, key)
+		if err != nil {
+			notify = true
+			onChange(nil, err)
+		} else if notify || changed {
+			notify = false
+			onChange(value, nil)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetPartition obtém todas as configurações da partição indexadas pela chave; sem SortKey retorna
+// os atributos do item da partição
+func (p *DynamoDBConfigProvider) GetPartition(partition string) (map[string]interface{}, error) {
+	if p.layout.SortKey == "" {
+		item, found, err := p.getItem(partition, "")
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("configuration partition %s not found", partition)
+		}
+
+		values := make(map[string]interface{}, len(item))
+		for name, value := range item {
+			if name != p.layout.PartitionKey && name != p.layout.VersionAttribute {
+				values[name] = decodeConfigValue(value)
+			}
+		}
+		return values, nil
+	}
+
+	it, err := p.cc.QueryDynamoDB(p.layout.TableName, "#pk = :pk", map[string]interface{}{":pk": partition},
+		WithDynamoDBNames(map[string]string{"#pk": p.layout.PartitionKey}), WithDynamoDBConsistentRead())
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]interface{})
+	for {
+		var item map[string]interface{}
+		if err := it.Next(&item); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		key, ok := item[p.layout.SortKey].(string)
+		if !ok {
+			return nil, fmt.Errorf("configuration sort key %s must be a string", p.layout.SortKey)
+		}
+		values[key] = decodeConfigValue(item[p.layout.ValueAttribute])
+	}
+	return values, nil
+}
+
+// load lê a configuração da tabela e retorna seu valor decodificado e a versão usada na detecção de mudanças
+func (p *DynamoDBConfigProvider) load(partition, key string) (interface{}, interface{}, error) {
+	item, found, err := p.getItem(partition, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	valueAttribute := p.layout.ValueAttribute
+	if p.layout.SortKey == "" {
+		valueAttribute = key
+	}
+
+	raw, ok := item[valueAttribute]
+	if !found || !ok {
+		return nil, nil, fmt.Errorf("configuration %s not found in partition %s", key, partition)
+	}
+
+	version := raw
+	if p.layout.VersionAttribute != "" {
+		version = item[p.layout.VersionAttribute]
+	}
+	return decodeConfigValue(raw), version, nil
+}
+
+func (p *DynamoDBConfigProvider) getItem(partition, key string) (map[string]interface{}, bool, error) {
+	itemKey := map[string]interface{}{p.layout.PartitionKey: partition}
+	if p.layout.SortKey != "" {
+		itemKey[p.layout.SortKey] = key
+	}
+
+	var item map[string]interface{}
+	found, err := p.cc.GetDynamoDBItem(p.layout.TableName, itemKey, &item, WithDynamoDBConsistentRead())
+	if err != nil {
+		return nil, false, err
+	}
+	return item, found, nil
+}
+
+// decodeConfigValue converte textos com documentos JSON em mapas e listas, mantendo os demais valores
+func decodeConfigValue(value interface{}) interface{} {
+	text, ok := value.(string)
+	if !ok {
+		return value
+	}
+
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return value
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(trimmed), &decoded); err != nil {
+		return value
+	}
+	return decoded
+}
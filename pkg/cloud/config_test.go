@@ -0,0 +1,158 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeConfigContext responde GetDynamoDBItem com os itens informados, contando as leituras
+type fakeConfigContext struct {
+	CloudContext
+	items map[string]map[string]interface{}
+	reads int
+	err   error
+}
+
+func (f *fakeConfigContext) GetDynamoDBItem(tableName string, key interface{}, out interface{}, opts ...DynamoDBReadOption) (bool, error) {
+	f.reads++
+	if f.err != nil {
+		return false, f.err
+	}
+
+	keyValues := key.(map[string]interface{})
+	item, ok := f.items[keyValues["tenant"].(string)+"/"+keyValues["name"].(string)]
+	if !ok {
+		return false, nil
+	}
+
+	// copiar o item simulando a conversão feita pelo DynamoDB
+	body, _ := json.Marshal(item)
+	return true, json.Unmarshal(body, out)
+}
+
+func TestDynamoDBConfigProvider(t *testing.T) {
+	layout := DynamoDBConfigLayout{TableName: "config", PartitionKey: "tenant", SortKey: "name"}
+
+	t.Run("Decode JSON attributes and cache values", func(t *testing.T) {
+		fake := &fakeConfigContext{items: map[string]map[string]interface{}{
+			"acme/limits": {"tenant": "acme", "name": "limits", "value": `{"rps": 10}`},
+		}}
+		provider, err := NewDynamoDBConfigProvider(fake, layout, time.Minute)
+		assert.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			value, err := provider.GetValue("acme", "limits")
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"rps": float64(10)}, value)
+		}
+		assert.Equal(t, 1, fake.reads)
+
+		provider.Invalidate("acme", "limits")
+		_, err = provider.GetValue("acme", "limits")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, fake.reads)
+	})
+
+	t.Run("Revalidate detects changes", func(t *testing.T) {
+		fake := &fakeConfigContext{items: map[string]map[string]interface{}{
+			"acme/mode": {"tenant": "acme", "name": "mode", "value": "blue"},
+		}}
+		provider, _ := NewDynamoDBConfigProvider(fake, layout, time.Minute)
+
+		_, changed, err := provider.Revalidate("acme", "mode")
+		assert.NoError(t, err)
+		assert.True(t, changed)
+
+		_, changed, _ = provider.Revalidate("acme", "mode")
+		assert.False(t, changed)
+
+		fake.items["acme/mode"]["value"] = "green"
+		value, changed, _ := provider.Revalidate("acme", "mode")
+		assert.True(t, changed)
+		assert.Equal(t, "green", value)
+	})
+
+	t.Run("Watch reports the first value and stops with the context", func(t *testing.T) {
+		fake := &fakeConfigContext{items: map[string]map[string]interface{}{
+			"acme/mode": {"tenant": "acme", "name": "mode", "value": "blue"},
+		}}
+		provider, _ := NewDynamoDBConfigProvider(fake, layout, 0)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var values []interface{}
+		err := provider.Watch(ctx, "acme", "mode", time.Millisecond, func(value interface{}, err error) {
+			values = append(values, value)
+			cancel()
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, []interface{}{"blue"}, values)
+	})
+
+	t.Run("Watch reports a value already cached", func(t *testing.T) {
+		fake := &fakeConfigContext{items: map[string]map[string]interface{}{
+			"acme/mode": {"tenant": "acme", "name": "mode", "value": "blue"},
+		}}
+		provider, _ := NewDynamoDBConfigProvider(fake, layout, time.Minute)
+		_, err := provider.GetValue("acme", "mode")
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var values []interface{}
+		err = provider.Watch(ctx, "acme", "mode", time.Millisecond, func(value interface{}, err error) {
+			values = append(values, value)
+			cancel()
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, []interface{}{"blue"}, values)
+	})
+
+	t.Run("Watch reports the value again when an error clears", func(t *testing.T) {
+		fake := &fakeConfigContext{items: map[string]map[string]interface{}{
+			"acme/mode": {"tenant": "acme", "name": "mode", "value": "blue"},
+		}}
+		provider, _ := NewDynamoDBConfigProvider(fake, layout, 0)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var values []interface{}
+		var errs []error
+		_ = provider.Watch(ctx, "acme", "mode", time.Millisecond, func(value interface{}, err error) {
+			values = append(values, value)
+			errs = append(errs, err)
+			switch len(values) {
+			case 1:
+				fake.err = errors.New("throttled")
+			case 2:
+				fake.err = nil
+			default:
+				cancel()
+			}
+		})
+
+		assert.Equal(t, []interface{}{"blue", nil, "blue"}, values)
+		assert.Error(t, errs[1])
+		assert.NoError(t, errs[2])
+	})
+
+	t.Run("Watch rejects a non-positive interval", func(t *testing.T) {
+		provider, _ := NewDynamoDBConfigProvider(&fakeConfigContext{}, layout, 0)
+
+		err := provider.Watch(context.Background(), "acme", "mode", 0, func(value interface{}, err error) {
+			t.Fatal("onChange must not be called")
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing configuration", func(t *testing.T) {
+		provider, _ := NewDynamoDBConfigProvider(&fakeConfigContext{}, layout, time.Minute)
+
+		_, err := provider.GetValue("acme", "missing")
+		assert.Error(t, err)
+	})
+}
@@ -1,14 +1,31 @@
 package cloud
 
 import (
+	"context"
+	"crypto/ed25519"
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"io"
+	"sort"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
+	kmsapi "github.com/aws/aws-sdk-go/service/kms"
+	"github.com/raywall/cloud-easy-connector/internal/aws/appconfig"
+	"github.com/raywall/cloud-easy-connector/internal/aws/dynamodb"
+	"github.com/raywall/cloud-easy-connector/internal/aws/eventbridge"
+	"github.com/raywall/cloud-easy-connector/internal/aws/kinesis"
+	"github.com/raywall/cloud-easy-connector/internal/aws/kms"
+	"github.com/raywall/cloud-easy-connector/internal/aws/lambda"
+	"github.com/raywall/cloud-easy-connector/internal/aws/rds"
 	"github.com/raywall/cloud-easy-connector/internal/aws/s3"
 	"github.com/raywall/cloud-easy-connector/internal/aws/secretsmanager"
+	"github.com/raywall/cloud-easy-connector/internal/aws/sns"
+	"github.com/raywall/cloud-easy-connector/internal/aws/sqs"
 	"github.com/raywall/cloud-easy-connector/internal/aws/ssm"
+	"github.com/raywall/cloud-easy-connector/internal/aws/sts"
 	"github.com/raywall/cloud-easy-connector/pkg/auth"
 )
 
@@ -27,11 +44,520 @@ const (
 	S3Context ContextType = iota
 	SSMContext
 	SecretsManagerContext
+	KMSContext
+	DynamoDBContext
+	SQSContext
+	SNSContext
+	EventBridgeContext
+	KinesisContext
+	LambdaContext
+	AppConfigContext
 
 	TextSecret SecretType = "text"
 	JSONSecret SecretType = "json"
 )
 
+// KMSDataKey é uma chave de dados em texto claro acompanhada da sua versão cifrada pelo KMS
+type KMSDataKey = kms.DataKey
+
+// S3GetOption configura uma leitura individual de objeto S3
+type S3GetOption = s3.GetOption
+
+// S3Format identifica o formato usado para decodificar um objeto S3
+type S3Format = s3.Format
+
+const (
+	S3FormatAuto = s3.FormatAuto
+	S3FormatJSON = s3.FormatJSON
+	S3FormatYAML = s3.FormatYAML
+	S3FormatCSV  = s3.FormatCSV
+	S3FormatText = s3.FormatText
+
+	S3FormatTOML       = s3.FormatTOML
+	S3FormatDotEnv     = s3.FormatDotEnv
+	S3FormatProperties = s3.FormatProperties
+	S3FormatXML        = s3.FormatXML
+	S3FormatNDJSON     = s3.FormatNDJSON
+	S3FormatParquet    = s3.FormatParquet
+)
+
+// S3Decoder converte o conteúdo de um objeto S3 em um valor Go
+type S3Decoder = s3.Decoder
+
+// S3DecoderFunc permite utilizar uma função comum como S3Decoder
+type S3DecoderFunc = s3.DecoderFunc
+
+// S3DecoderRegistration associa um S3Decoder às extensões e Content-Types que identificam o formato
+type S3DecoderRegistration = s3.DecoderRegistration
+
+// RegisterS3Decoder registra um decoder de formato para as leituras de objetos S3
+func RegisterS3Decoder(registration S3DecoderRegistration) error {
+	return s3.RegisterDecoder(registration)
+}
+
+// WithS3Format ignora a detecção automática e decodifica o objeto no formato informado
+func WithS3Format(format S3Format) S3GetOption {
+	return s3.WithFormat(format)
+}
+
+// S3PutOption configura uma gravação individual de objeto S3
+type S3PutOption = s3.PutOption
+
+// S3PutResult contém o ETag e o VersionId do objeto gravado
+type S3PutResult = s3.PutResult
+
+// WithS3ContentType sobrescreve o Content-Type inferido pela extensão do arquivo
+func WithS3ContentType(contentType string) S3PutOption {
+	return s3.WithContentType(contentType)
+}
+
+// WithS3SSEKMS habilita a criptografia SSE-KMS; keyID vazio utiliza a chave gerenciada aws/s3
+func WithS3SSEKMS(keyID string) S3PutOption {
+	return s3.WithSSEKMS(keyID)
+}
+
+// WithS3Metadata adiciona metadados de usuário ao objeto gravado
+func WithS3Metadata(metadata map[string]string) S3PutOption {
+	return s3.WithMetadata(metadata)
+}
+
+// WithS3Tags adiciona tags ao objeto gravado
+func WithS3Tags(tags map[string]string) S3PutOption {
+	return s3.WithTags(tags)
+}
+
+// S3CSVIterator percorre um objeto CSV linha a linha
+type S3CSVIterator = s3.CSVIterator
+
+// S3CSVOptions configura a interpretação de objetos CSV
+type S3CSVOptions = s3.CSVOptions
+
+// S3ColumnType define o tipo Go para o qual uma coluna CSV é convertida
+type S3ColumnType = s3.ColumnType
+
+const (
+	S3ColumnString = s3.ColumnString
+	S3ColumnInt    = s3.ColumnInt
+	S3ColumnFloat  = s3.ColumnFloat
+	S3ColumnBool   = s3.ColumnBool
+	S3ColumnTime   = s3.ColumnTime
+)
+
+// WithS3CSVOptions configura delimitador, cabeçalho, tipagem de colunas e demais opções de CSV
+func WithS3CSVOptions(csvOptions S3CSVOptions) S3GetOption {
+	return s3.WithCSVOptions(csvOptions)
+}
+
+// S3NDJSONDecoder decodifica um objeto NDJSON registro a registro
+type S3NDJSONDecoder = s3.NDJSONDecoder
+
+// WithS3PartSize define o tamanho, em bytes, de cada parte do upload multipart
+func WithS3PartSize(partSize int64) S3PutOption {
+	return s3.WithPartSize(partSize)
+}
+
+// WithS3Concurrency define quantas partes do upload multipart são enviadas em paralelo
+func WithS3Concurrency(concurrency int) S3PutOption {
+	return s3.WithConcurrency(concurrency)
+}
+
+// S3PresignedRequest contém a URL assinada e os cabeçalhos que o cliente deve enviar
+type S3PresignedRequest = s3.PresignedRequest
+
+// S3PostPolicy descreve as restrições de um upload via formulário HTML (POST)
+type S3PostPolicy = s3.PostPolicy
+
+// S3PresignedPost contém a URL e os campos de formulário de um upload via POST
+type S3PresignedPost = s3.PresignedPost
+
+// WithS3ContentLength exige o tamanho informado em uploads com URL assinada
+func WithS3ContentLength(contentLength int64) S3PutOption {
+	return s3.WithContentLength(contentLength)
+}
+
+// S3ObjectSummary descreve um objeto retornado pela listagem do bucket
+type S3ObjectSummary = s3.ObjectSummary
+
+// S3LoadError reúne as falhas individuais de LoadS3Prefix, indexadas pela chave do objeto
+type S3LoadError = s3.LoadError
+
+// WithS3Workers define quantos objetos são baixados simultaneamente em LoadS3Prefix
+func WithS3Workers(workers int) S3GetOption {
+	return s3.WithWorkers(workers)
+}
+
+// S3Object contém o conteúdo decodificado de um objeto S3 junto com os dados da revisão lida
+type S3Object = s3.Object
+
+// S3ObjectVersion descreve uma versão de um objeto em um bucket versionado
+type S3ObjectVersion = s3.ObjectVersion
+
+// WithS3VersionID lê a versão informada do objeto em vez da mais recente
+func WithS3VersionID(versionID string) S3GetOption {
+	return s3.WithVersionID(versionID)
+}
+
+// WithS3Tagging inclui as tags do objeto no resultado de GetS3Object
+func WithS3Tagging() S3GetOption {
+	return s3.WithTagging()
+}
+
+// S3SelectIterator percorre os registros retornados pelo S3 Select
+type S3SelectIterator = s3.SelectIterator
+
+// S3Signer assina o conteúdo gravado no S3
+type S3Signer = s3.Signer
+
+// S3Verifier valida a assinatura do conteúdo lido do S3
+type S3Verifier = s3.Verifier
+
+// S3SignatureLocation indica onde a assinatura destacada do objeto é armazenada
+type S3SignatureLocation = s3.SignatureLocation
+
+const (
+	S3SignatureSibling  = s3.SignatureSibling
+	S3SignatureMetadata = s3.SignatureMetadata
+)
+
+// ErrS3InvalidSignature indica que o objeto não possui uma assinatura válida
+var ErrS3InvalidSignature = s3.ErrInvalidSignature
+
+// NewS3Ed25519Signer cria um S3Signer com a chave privada Ed25519 informada
+func NewS3Ed25519Signer(privateKey ed25519.PrivateKey) S3Signer {
+	return s3.NewEd25519Signer(privateKey)
+}
+
+// NewS3Ed25519Verifier cria um S3Verifier com a chave pública Ed25519 informada
+func NewS3Ed25519Verifier(publicKey ed25519.PublicKey) S3Verifier {
+	return s3.NewEd25519Verifier(publicKey)
+}
+
+// WithS3Signature assina o conteúdo gravado e armazena a assinatura no local informado
+func WithS3Signature(signer S3Signer, location S3SignatureLocation) S3PutOption {
+	return s3.WithSignature(signer, location)
+}
+
+// WithS3Query aplica uma expressão JMESPath sobre o documento JSON, YAML ou CSV decodificado
+func WithS3Query(expression string) S3GetOption {
+	return s3.WithQuery(expression)
+}
+
+// DynamoDBReadOption configura uma leitura individual de itens DynamoDB
+type DynamoDBReadOption = dynamodb.ReadOption
+
+// DynamoDBWriteOption configura uma gravação individual de item DynamoDB
+type DynamoDBWriteOption = dynamodb.WriteOption
+
+// DynamoDBQueryIterator percorre os itens de uma consulta DynamoDB, página a página
+type DynamoDBQueryIterator = dynamodb.QueryIterator
+
+// ErrDynamoDBConditionFailed indica que a condição de uma gravação condicional não foi satisfeita
+var ErrDynamoDBConditionFailed = dynamodb.ErrConditionFailed
+
+// WithDynamoDBConsistentRead solicita leitura fortemente consistente
+func WithDynamoDBConsistentRead() DynamoDBReadOption {
+	return dynamodb.WithConsistentRead()
+}
+
+// WithDynamoDBIndex consulta o índice secundário informado
+func WithDynamoDBIndex(indexName string) DynamoDBReadOption {
+	return dynamodb.WithIndex(indexName)
+}
+
+// WithDynamoDBPageSize limita a quantidade de itens avaliados por página
+func WithDynamoDBPageSize(pageSize int64) DynamoDBReadOption {
+	return dynamodb.WithPageSize(pageSize)
+}
+
+// WithDynamoDBDescending percorre a chave de ordenação em ordem decrescente
+func WithDynamoDBDescending() DynamoDBReadOption {
+	return dynamodb.WithDescending()
+}
+
+// WithDynamoDBFilter aplica uma expressão de filtro aos itens consultados
+func WithDynamoDBFilter(expression string, values map[string]interface{}) DynamoDBReadOption {
+	return dynamodb.WithFilter(expression, values)
+}
+
+// WithDynamoDBProjection restringe os atributos retornados
+func WithDynamoDBProjection(expression string) DynamoDBReadOption {
+	return dynamodb.WithProjection(expression)
+}
+
+// WithDynamoDBNames define os placeholders de nomes de atributos (#nome) usados nas expressões
+func WithDynamoDBNames(names map[string]string) DynamoDBReadOption {
+	return dynamodb.WithNames(names)
+}
+
+// WithDynamoDBCondition grava o item apenas se a expressão for verdadeira
+func WithDynamoDBCondition(expression string, names map[string]string, values map[string]interface{}) DynamoDBWriteOption {
+	return dynamodb.WithCondition(expression, names, values)
+}
+
+// WithDynamoDBIfNotExists grava o item apenas se não houver outro com a mesma chave primária, incluindo
+// a chave de ordenação em tabelas com chave composta
+func WithDynamoDBIfNotExists(partitionKey string) DynamoDBWriteOption {
+	return dynamodb.WithIfNotExists(partitionKey)
+}
+
+// SQSMessage é uma mensagem a ser enviada para uma fila SQS
+type SQSMessage = sqs.Message
+
+// SQSBatchResult relaciona as mensagens enviadas em lote aos MessageIds e lista as falhas
+type SQSBatchResult = sqs.BatchResult
+
+// SQSBatchFailure descreve uma mensagem que o SQS não aceitou no envio em lote
+type SQSBatchFailure = sqs.BatchFailure
+
+// SQSReceivedMessage é uma mensagem recebida da fila e entregue ao SQSHandler
+type SQSReceivedMessage = sqs.ReceivedMessage
+
+// SQSHandler processa uma mensagem; a mensagem é removida da fila somente quando não há erro
+type SQSHandler = sqs.Handler
+
+// SQSConsumerOption configura o consumidor de ConsumeSQS
+type SQSConsumerOption = sqs.ConsumerOption
+
+// WithSQSConcurrency limita as mensagens processadas simultaneamente
+func WithSQSConcurrency(concurrency int) SQSConsumerOption {
+	return sqs.WithConcurrency(concurrency)
+}
+
+// WithSQSMaxMessages limita as mensagens solicitadas por ReceiveMessage, entre 1 e 10
+func WithSQSMaxMessages(maxMessages int64) SQSConsumerOption {
+	return sqs.WithMaxMessages(maxMessages)
+}
+
+// WithSQSWaitTime define a duração do long polling, até 20 segundos
+func WithSQSWaitTime(waitTime time.Duration) SQSConsumerOption {
+	return sqs.WithWaitTime(waitTime)
+}
+
+// WithSQSVisibilityTimeout define o tempo de visibilidade renovado enquanto o handler estiver em execução
+func WithSQSVisibilityTimeout(timeout time.Duration) SQSConsumerOption {
+	return sqs.WithVisibilityTimeout(timeout)
+}
+
+// WithSQSDeleteInterval define o tempo máximo de espera para a remoção em lote das mensagens processadas
+func WithSQSDeleteInterval(interval time.Duration) SQSConsumerOption {
+	return sqs.WithDeleteInterval(interval)
+}
+
+// WithSQSErrorHandler recebe os erros que não interrompem o consumidor
+func WithSQSErrorHandler(onError func(err error)) SQSConsumerOption {
+	return sqs.WithErrorHandler(onError)
+}
+
+// SNSMessage é uma mensagem a ser publicada em um tópico SNS
+type SNSMessage = sns.Message
+
+// SNSBatchResult relaciona as mensagens publicadas em lote aos MessageIds e lista as falhas
+type SNSBatchResult = sns.BatchResult
+
+// SNSBatchFailure descreve uma mensagem que o SNS não aceitou na publicação em lote
+type SNSBatchFailure = sns.BatchFailure
+
+// EventBridgeEvent é um evento de domínio a ser publicado no EventBridge
+type EventBridgeEvent = eventbridge.Event
+
+// EventBridgePutOption configura uma publicação de eventos no EventBridge
+type EventBridgePutOption = eventbridge.PutOption
+
+// EventBridgeDetail é implementado pelos tipos de detalhe que definem o próprio DetailType
+type EventBridgeDetail = eventbridge.Detail
+
+// NewEventBridgeEvent cria o evento com o DetailType definido pelo tipo do detalhe
+func NewEventBridgeEvent[T EventBridgeDetail](source string, detail T) EventBridgeEvent {
+	return eventbridge.NewEvent(source, detail)
+}
+
+// EventBridgeEntryError descreve a falha de um evento individual
+type EventBridgeEntryError = eventbridge.EntryError
+
+// EventBridgePutEventsError reúne as falhas individuais de PutEventBridgeEvents, indexadas pela posição do evento
+type EventBridgePutEventsError = eventbridge.PutEventsError
+
+// WithEventBridgeBus publica os eventos sem EventBusName no barramento informado
+func WithEventBridgeBus(name string) EventBridgePutOption {
+	return eventbridge.WithEventBus(name)
+}
+
+// WithEventBridgeMaxAttempts limita as tentativas de publicação de cada evento
+func WithEventBridgeMaxAttempts(attempts int) EventBridgePutOption {
+	return eventbridge.WithMaxAttempts(attempts)
+}
+
+// StreamProducer acumula registros e os entrega em lotes ao Kinesis Data Streams ou ao Firehose
+type StreamProducer = kinesis.Producer
+
+// StreamProducerOption configura um StreamProducer
+type StreamProducerOption = kinesis.ProducerOption
+
+// StreamPartitionKeyFunc escolhe a chave de partição de um registro do Kinesis Data Streams
+type StreamPartitionKeyFunc = kinesis.PartitionKeyFunc
+
+// StreamFailedRecord é um registro que não foi entregue depois de todas as tentativas
+type StreamFailedRecord = kinesis.FailedRecord
+
+// StreamDeliveryError reúne os registros que não foram entregues desde o último Flush
+type StreamDeliveryError = kinesis.DeliveryError
+
+// ErrStreamProducerClosed indica uma gravação em um StreamProducer já encerrado
+var ErrStreamProducerClosed = kinesis.ErrProducerClosed
+
+// StreamRandomPartitionKey distribui os registros aleatoriamente entre os shards
+func StreamRandomPartitionKey() StreamPartitionKeyFunc {
+	return kinesis.RandomPartitionKey()
+}
+
+// StreamFixedPartitionKey grava todos os registros no mesmo shard
+func StreamFixedPartitionKey(key string) StreamPartitionKeyFunc {
+	return kinesis.FixedPartitionKey(key)
+}
+
+// StreamHashPartitionKey usa o hash do conteúdo como chave de partição
+func StreamHashPartitionKey() StreamPartitionKeyFunc {
+	return kinesis.HashPartitionKey()
+}
+
+// StreamJSONFieldPartitionKey usa o valor de um campo do documento JSON como chave de partição
+func StreamJSONFieldPartitionKey(field string) StreamPartitionKeyFunc {
+	return kinesis.JSONFieldPartitionKey(field)
+}
+
+// WithStreamFlushInterval define o tempo máximo que um registro aguarda no buffer
+func WithStreamFlushInterval(interval time.Duration) StreamProducerOption {
+	return kinesis.WithFlushInterval(interval)
+}
+
+// WithStreamMaxBufferedBytes limita a memória usada pelos registros ainda não entregues
+func WithStreamMaxBufferedBytes(maxBytes int) StreamProducerOption {
+	return kinesis.WithMaxBufferedBytes(maxBytes)
+}
+
+// WithStreamMaxAttempts limita as tentativas de entrega de cada registro
+func WithStreamMaxAttempts(attempts int) StreamProducerOption {
+	return kinesis.WithMaxAttempts(attempts)
+}
+
+// WithStreamPartitionKey define a estratégia de chave de partição dos registros
+func WithStreamPartitionKey(strategy StreamPartitionKeyFunc) StreamProducerOption {
+	return kinesis.WithPartitionKey(strategy)
+}
+
+// WithStreamDelimiter acrescenta o delimitador ao final de cada registro
+func WithStreamDelimiter(delimiter []byte) StreamProducerOption {
+	return kinesis.WithDelimiter(delimiter)
+}
+
+// WithStreamAggregation agrupa os registros com a mesma chave de partição no formato de agregação do KPL
+func WithStreamAggregation() StreamProducerOption {
+	return kinesis.WithAggregation()
+}
+
+// WithStreamRecordErrorHandler recebe cada registro que não foi entregue
+func WithStreamRecordErrorHandler(onError func(record StreamFailedRecord)) StreamProducerOption {
+	return kinesis.WithRecordErrorHandler(onError)
+}
+
+// LambdaInvokeOption configura uma invocação de função Lambda
+type LambdaInvokeOption = lambda.InvokeOption
+
+// LambdaInvokeResult contém a resposta bruta de uma invocação
+type LambdaInvokeResult = lambda.InvokeResult
+
+// LambdaFunctionError indica que a função foi executada, mas retornou erro
+type LambdaFunctionError = lambda.FunctionError
+
+// WithLambdaQualifier invoca a versão ou o alias informado
+func WithLambdaQualifier(qualifier string) LambdaInvokeOption {
+	return lambda.WithQualifier(qualifier)
+}
+
+// WithLambdaAsync invoca a função com o tipo Event, sem aguardar a resposta
+func WithLambdaAsync() LambdaInvokeOption {
+	return lambda.WithAsync()
+}
+
+// WithLambdaLogTail solicita os últimos 4 KB do log de execução
+func WithLambdaLogTail() LambdaInvokeOption {
+	return lambda.WithLogTail()
+}
+
+// InvokeLambda converte a requisição para JSON, invoca a função pelo LambdaContext e converte a resposta
+// para Resp; erros da função são informados como *LambdaFunctionError
+func InvokeLambda[Req, Resp any](cc CloudContext, functionName string, request Req, opts ...LambdaInvokeOption) (Resp, *LambdaInvokeResult, error) {
+	return lambda.Invoke[Req, Resp](cc.InvokeLambdaRaw, functionName, request, opts...)
+}
+
+// AppConfigFlags avalia as feature flags de um perfil do AppConfig
+type AppConfigFlags = appconfig.FeatureFlags
+
+// CallerIdentity identifica a conta e o principal associados às credenciais em uso
+type CallerIdentity = sts.Identity
+
+// AssumeRoleOption configura a sessão criada por AssumeRole
+type AssumeRoleOption = sts.AssumeRoleOption
+
+// WithRoleSessionName identifica a sessão assumida no CloudTrail
+func WithRoleSessionName(name string) AssumeRoleOption {
+	return sts.WithSessionName(name)
+}
+
+// WithRoleExternalID informa o ExternalID exigido pela política de confiança do papel
+func WithRoleExternalID(externalID string) AssumeRoleOption {
+	return sts.WithExternalID(externalID)
+}
+
+// WithRoleDuration define a validade de cada credencial emitida para o papel
+func WithRoleDuration(duration time.Duration) AssumeRoleOption {
+	return sts.WithDuration(duration)
+}
+
+// WithRolePolicy restringe as permissões da sessão assumida com uma política inline
+func WithRolePolicy(policy string) AssumeRoleOption {
+	return sts.WithPolicy(policy)
+}
+
+// RDSSecret é o segredo no formato padrão do RDS no Secrets Manager
+type RDSSecret = rds.Secret
+
+// RDSEngine identifica o formato de DSN gerado
+type RDSEngine = rds.Engine
+
+const (
+	RDSEnginePostgres = rds.EnginePostgres
+	RDSEngineMySQL    = rds.EngineMySQL
+)
+
+// RDSOption configura a geração do DSN a partir do segredo do RDS
+type RDSOption = rds.Option
+
+// WithRDSEngine força o formato de DSN, ignorando o engine do segredo
+func WithRDSEngine(engine RDSEngine) RDSOption {
+	return rds.WithEngine(engine)
+}
+
+// WithRDSDatabase conecta ao banco informado no lugar do dbname do segredo
+func WithRDSDatabase(database string) RDSOption {
+	return rds.WithDatabase(database)
+}
+
+// WithRDSParams acrescenta parâmetros ao DSN
+func WithRDSParams(params map[string]string) RDSOption {
+	return rds.WithParams(params)
+}
+
+// WithRDSIAMAuth autentica com tokens IAM do RDS, gerados com as credenciais do contexto, no lugar da senha
+func WithRDSIAMAuth() RDSOption {
+	return rds.WithIAMAuth()
+}
+
+// WithRDSAuthErrorCheck substitui a identificação padrão das falhas de autenticação do driver
+func WithRDSAuthErrorCheck(isAuthError func(err error) bool) RDSOption {
+	return rds.WithAuthErrorCheck(isAuthError)
+}
+
 type CloudContextObject struct {
 	awsSession           *session.Session
 	awsContextCollection map[ContextType]interface{}
@@ -40,9 +566,58 @@ type CloudContextObject struct {
 
 // CloudContext é a interface principal para interação com recursos AWS
 type CloudContext interface {
-	GetS3ObjectValue(bucketName, keyName string) (interface{}, error)
+	GetS3ObjectValue(bucketName, keyName string, opts ...S3GetOption) (interface{}, error)
+	GetS3Object(bucketName, keyName string, opts ...S3GetOption) (*S3Object, error)
+	ListS3ObjectVersions(bucketName, keyName string) ([]S3ObjectVersion, error)
+	SelectS3Object(bucketName, keyName, expression string, inputFormat S3Format, opts ...S3GetOption) (*S3SelectIterator, error)
+	PutS3ObjectValue(bucketName, keyName string, value interface{}, opts ...S3PutOption) (*S3PutResult, error)
+	RevalidateS3ObjectValue(bucketName, keyName string, opts ...S3GetOption) (interface{}, bool, error)
+	GetS3ObjectReader(bucketName, keyName string) (io.ReadCloser, error)
+	GetS3ObjectRange(bucketName, keyName string, offset, length int64) (io.ReadCloser, error)
+	NewS3CSVIterator(bucketName, keyName string, opts ...S3GetOption) (*S3CSVIterator, error)
+	NewS3NDJSONDecoder(bucketName, keyName string, opts ...S3GetOption) (*S3NDJSONDecoder, error)
+	UploadS3Object(bucketName, keyName string, body io.Reader, opts ...S3PutOption) (*S3PutResult, error)
+	ListS3Objects(bucketName, prefix string) ([]S3ObjectSummary, error)
+	ListS3ObjectPages(bucketName, prefix string, fn func(page []S3ObjectSummary, lastPage bool) bool) error
+	LoadS3Prefix(bucketName, prefix string, opts ...S3GetOption) (map[string]interface{}, error)
+	PresignS3Get(bucketName, keyName string, expiry time.Duration) (*S3PresignedRequest, error)
+	PresignS3Put(bucketName, keyName string, expiry time.Duration, opts ...S3PutOption) (*S3PresignedRequest, error)
+	PresignS3Post(bucketName string, policy S3PostPolicy) (*S3PresignedPost, error)
+	EnableS3Encryption(kmsKeyID string, encryptionContext map[string]string) error
+	DisableS3Encryption()
+	EnableS3SignatureVerification(verifier S3Verifier, location S3SignatureLocation) error
+	DisableS3SignatureVerification()
+	NewS3KMSSigner(keyID, algorithm string) S3Signer
+	NewS3KMSVerifier(keyID, algorithm string) S3Verifier
 	GetParameterValue(parameterName string, withDecryption bool) (interface{}, error)
 	GetSecretValue(secretName string, secretType SecretType) (interface{}, error)
+	EncryptWithKMS(keyID string, plaintext []byte, encryptionContext map[string]string) ([]byte, error)
+	DecryptWithKMS(ciphertext []byte, encryptionContext map[string]string) ([]byte, error)
+	GenerateKMSDataKey(keyID string, encryptionContext map[string]string) (*KMSDataKey, error)
+	DecryptKMSDataKey(ciphertext []byte, encryptionContext map[string]string) (*KMSDataKey, error)
+	SignWithKMS(keyID string, message []byte, algorithm string) ([]byte, error)
+	VerifyWithKMS(keyID string, message, signature []byte, algorithm string) (bool, error)
+	ConfigureKMSDataKeyCache(ttl time.Duration, maxUses int) error
+	GetDynamoDBItem(tableName string, key interface{}, out interface{}, opts ...DynamoDBReadOption) (bool, error)
+	PutDynamoDBItem(tableName string, item interface{}, opts ...DynamoDBWriteOption) error
+	QueryDynamoDB(tableName, keyCondition string, values map[string]interface{}, opts ...DynamoDBReadOption) (*DynamoDBQueryIterator, error)
+	BatchGetDynamoDBItems(tableName string, keys []interface{}, out interface{}, opts ...DynamoDBReadOption) error
+	SendSQSMessage(queueURL string, message SQSMessage) (string, error)
+	SendSQSMessageBatch(queueURL string, messages []SQSMessage) (*SQSBatchResult, error)
+	ConsumeSQS(ctx context.Context, queueURL string, handler SQSHandler, opts ...SQSConsumerOption) error
+	PublishSNSMessage(topicARN string, message SNSMessage) (string, error)
+	PublishSNSMessageBatch(topicARN string, messages []SNSMessage) (*SNSBatchResult, error)
+	PutEventBridgeEvents(events []EventBridgeEvent, opts ...EventBridgePutOption) ([]string, error)
+	NewKinesisProducer(streamName string, opts ...StreamProducerOption) (*StreamProducer, error)
+	NewFirehoseProducer(deliveryStreamName string, opts ...StreamProducerOption) (*StreamProducer, error)
+	InvokeLambdaRaw(functionName string, payload []byte, opts ...LambdaInvokeOption) (*LambdaInvokeResult, error)
+	GetAppConfigValue(application, environment, profile string) (interface{}, error)
+	GetAppConfigFlags(application, environment, profile string) (*AppConfigFlags, error)
+	GetAppConfigLastError(application, environment, profile string) error
+	WhoAmI() (*CallerIdentity, error)
+	AssumeRole(roleArn string, opts ...AssumeRoleOption) (CloudContext, error)
+	GetRDSDSN(secretName string, opts ...RDSOption) (string, error)
+	NewRDSConnector(secretName string, drv driver.Driver, opts ...RDSOption) (driver.Connector, error)
 	NewAutoManagedToken(url, clientId, clientSecret string, certSkipVerify bool)
 	GetAutoManagedToken() auth.AutoManagedToken
 }
@@ -66,11 +641,20 @@ func NewAwsCloudContext(region, endpoint string, availableResources *CloudContex
 		sess.Config.Endpoint = aws.String(endpoint)
 	}
 
+	cloudContext, err := newCloudContext(sess, *availableResources)
+	if err != nil {
+		return nil, err
+	}
+	return cloudContext, nil
+}
+
+// newCloudContext cria os contextos dos recursos informados sobre a sessão AWS
+func newCloudContext(sess *session.Session, resources CloudContextList) (*CloudContextObject, error) {
 	cloudContext := CloudContextObject{
 		awsSession:           sess,
 		awsContextCollection: make(map[ContextType]interface{}, 0),
 	}
-	for _, res := range *availableResources {
+	for _, res := range resources {
 		switch res {
 		case S3Context:
 			cloudContext.awsContextCollection[res] = s3.NewS3Context(cloudContext.awsSession)
@@ -84,6 +668,38 @@ func NewAwsCloudContext(region, endpoint string, availableResources *CloudContex
 			cloudContext.awsContextCollection[res] = secretsmanager.NewSecretsManagerContext(cloudContext.awsSession)
 			continue
 
+		case KMSContext:
+			cloudContext.awsContextCollection[res] = kms.NewKMSContext(cloudContext.awsSession)
+			continue
+
+		case DynamoDBContext:
+			cloudContext.awsContextCollection[res] = dynamodb.NewDynamoDBContext(cloudContext.awsSession)
+			continue
+
+		case SQSContext:
+			cloudContext.awsContextCollection[res] = sqs.NewSQSContext(cloudContext.awsSession)
+			continue
+
+		case SNSContext:
+			cloudContext.awsContextCollection[res] = sns.NewSNSContext(cloudContext.awsSession)
+			continue
+
+		case EventBridgeContext:
+			cloudContext.awsContextCollection[res] = eventbridge.NewEventBridgeContext(cloudContext.awsSession)
+			continue
+
+		case KinesisContext:
+			cloudContext.awsContextCollection[res] = kinesis.NewKinesisContext(cloudContext.awsSession)
+			continue
+
+		case LambdaContext:
+			cloudContext.awsContextCollection[res] = lambda.NewLambdaContext(cloudContext.awsSession)
+			continue
+
+		case AppConfigContext:
+			cloudContext.awsContextCollection[res] = appconfig.NewAppConfigContext(cloudContext.awsSession)
+			continue
+
 		default:
 			return nil, fmt.Errorf("the ContextType was not identified: %v", res)
 		}
@@ -92,13 +708,165 @@ func NewAwsCloudContext(region, endpoint string, availableResources *CloudContex
 	return &cloudContext, nil
 }
 
-func (c *CloudContextObject) GetS3ObjectValue(bucketName, keyName string) (interface{}, error) {
+func (c *CloudContextObject) GetS3ObjectValue(bucketName, keyName string, opts ...S3GetOption) (interface{}, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).GetValue(bucketName, keyName, opts...)
+	}
+	return nil, errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) GetS3Object(bucketName, keyName string, opts ...S3GetOption) (*S3Object, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).GetObject(bucketName, keyName, opts...)
+	}
+	return nil, errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) ListS3ObjectVersions(bucketName, keyName string) ([]S3ObjectVersion, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).ListVersions(bucketName, keyName)
+	}
+	return nil, errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) SelectS3Object(bucketName, keyName, expression string, inputFormat S3Format, opts ...S3GetOption) (*S3SelectIterator, error) {
 	if ctx, ok := c.awsContextCollection[S3Context]; ok {
-		return (ctx.(*s3.S3CloudContext)).GetValue(bucketName, keyName)
+		return (ctx.(*s3.S3CloudContext)).Select(bucketName, keyName, expression, inputFormat, opts...)
 	}
 	return nil, errors.New("can't find the available context to s3 resource")
 }
 
+func (c *CloudContextObject) PutS3ObjectValue(bucketName, keyName string, value interface{}, opts ...S3PutOption) (*S3PutResult, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).PutValue(bucketName, keyName, value, opts...)
+	}
+	return nil, errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) RevalidateS3ObjectValue(bucketName, keyName string, opts ...S3GetOption) (interface{}, bool, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).Revalidate(bucketName, keyName, opts...)
+	}
+	return nil, false, errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) GetS3ObjectReader(bucketName, keyName string) (io.ReadCloser, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).GetReader(bucketName, keyName)
+	}
+	return nil, errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) GetS3ObjectRange(bucketName, keyName string, offset, length int64) (io.ReadCloser, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).GetRange(bucketName, keyName, offset, length)
+	}
+	return nil, errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) NewS3CSVIterator(bucketName, keyName string, opts ...S3GetOption) (*S3CSVIterator, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).NewCSVIterator(bucketName, keyName, opts...)
+	}
+	return nil, errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) NewS3NDJSONDecoder(bucketName, keyName string, opts ...S3GetOption) (*S3NDJSONDecoder, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).NewNDJSONDecoder(bucketName, keyName, opts...)
+	}
+	return nil, errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) UploadS3Object(bucketName, keyName string, body io.Reader, opts ...S3PutOption) (*S3PutResult, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).Upload(bucketName, keyName, body, opts...)
+	}
+	return nil, errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) ListS3Objects(bucketName, prefix string) ([]S3ObjectSummary, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).List(bucketName, prefix)
+	}
+	return nil, errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) ListS3ObjectPages(bucketName, prefix string, fn func(page []S3ObjectSummary, lastPage bool) bool) error {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).ListPages(bucketName, prefix, fn)
+	}
+	return errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) LoadS3Prefix(bucketName, prefix string, opts ...S3GetOption) (map[string]interface{}, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).LoadPrefix(bucketName, prefix, opts...)
+	}
+	return nil, errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) PresignS3Get(bucketName, keyName string, expiry time.Duration) (*S3PresignedRequest, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).PresignGet(bucketName, keyName, expiry)
+	}
+	return nil, errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) PresignS3Put(bucketName, keyName string, expiry time.Duration, opts ...S3PutOption) (*S3PresignedRequest, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).PresignPut(bucketName, keyName, expiry, opts...)
+	}
+	return nil, errors.New("can't find the available context to s3 resource")
+}
+
+func (c *CloudContextObject) PresignS3Post(bucketName string, policy S3PostPolicy) (*S3PresignedPost, error) {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).PresignPost(bucketName, policy)
+	}
+	return nil, errors.New("can't find the available context to s3 resource")
+}
+
+// EnableS3Encryption passa a cifrar no cliente, com chaves de dados do KMS, os objetos gravados por PutS3ObjectValue
+func (c *CloudContextObject) EnableS3Encryption(kmsKeyID string, encryptionContext map[string]string) error {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).EnableEncryption(kmsKeyID, encryptionContext)
+	}
+	return errors.New("can't find the available context to s3 resource")
+}
+
+// DisableS3Encryption volta a gravar os objetos sem criptografia no cliente
+func (c *CloudContextObject) DisableS3Encryption() {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		(ctx.(*s3.S3CloudContext)).DisableEncryption()
+	}
+}
+
+// EnableS3SignatureVerification passa a exigir uma assinatura válida em todos os objetos S3 lidos
+func (c *CloudContextObject) EnableS3SignatureVerification(verifier S3Verifier, location S3SignatureLocation) error {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		return (ctx.(*s3.S3CloudContext)).EnableSignatureVerification(verifier, location)
+	}
+	return errors.New("can't find the available context to s3 resource")
+}
+
+// DisableS3SignatureVerification deixa de exigir assinaturas nos objetos S3 lidos
+func (c *CloudContextObject) DisableS3SignatureVerification() {
+	if ctx, ok := c.awsContextCollection[S3Context]; ok {
+		(ctx.(*s3.S3CloudContext)).DisableSignatureVerification()
+	}
+}
+
+// NewS3KMSSigner cria um S3Signer que assina com a chave assimétrica do KMS, usando a sessão do contexto
+func (c *CloudContextObject) NewS3KMSSigner(keyID, algorithm string) S3Signer {
+	return s3.NewKMSSigner(kmsapi.New(c.awsSession), keyID, algorithm)
+}
+
+// NewS3KMSVerifier cria um S3Verifier que verifica com a chave assimétrica do KMS, usando a sessão do contexto
+func (c *CloudContextObject) NewS3KMSVerifier(keyID, algorithm string) S3Verifier {
+	return s3.NewKMSVerifier(kmsapi.New(c.awsSession), keyID, algorithm)
+}
+
 func (c *CloudContextObject) GetParameterValue(parameterName string, withDecryption bool) (interface{}, error) {
 	if ctx, ok := c.awsContextCollection[SSMContext]; ok {
 		return (ctx.(*ssm.SSMCloudContext)).GetValue(parameterName, withDecryption)
@@ -113,6 +881,248 @@ func (c *CloudContextObject) GetSecretValue(secretName string, secretType Secret
 	return nil, errors.New("can't find the available context to secrets manager resource")
 }
 
+// EncryptWithKMS cifra até 4 KB de dados com a chave KMS informada
+func (c *CloudContextObject) EncryptWithKMS(keyID string, plaintext []byte, encryptionContext map[string]string) ([]byte, error) {
+	if ctx, ok := c.awsContextCollection[KMSContext]; ok {
+		return (ctx.(*kms.KMSCloudContext)).Encrypt(keyID, plaintext, encryptionContext)
+	}
+	return nil, errors.New("can't find the available context to kms resource")
+}
+
+// DecryptWithKMS decifra dados cifrados pelo KMS com o mesmo contexto de criptografia usado na cifragem
+func (c *CloudContextObject) DecryptWithKMS(ciphertext []byte, encryptionContext map[string]string) ([]byte, error) {
+	if ctx, ok := c.awsContextCollection[KMSContext]; ok {
+		return (ctx.(*kms.KMSCloudContext)).Decrypt(ciphertext, encryptionContext)
+	}
+	return nil, errors.New("can't find the available context to kms resource")
+}
+
+// GenerateKMSDataKey gera, ou reaproveita do cache, uma chave de dados AES-256 para criptografia de envelope
+func (c *CloudContextObject) GenerateKMSDataKey(keyID string, encryptionContext map[string]string) (*KMSDataKey, error) {
+	if ctx, ok := c.awsContextCollection[KMSContext]; ok {
+		return (ctx.(*kms.KMSCloudContext)).GenerateDataKey(keyID, encryptionContext)
+	}
+	return nil, errors.New("can't find the available context to kms resource")
+}
+
+// DecryptKMSDataKey decifra, ou reaproveita do cache, uma chave de dados gerada por GenerateKMSDataKey
+func (c *CloudContextObject) DecryptKMSDataKey(ciphertext []byte, encryptionContext map[string]string) (*KMSDataKey, error) {
+	if ctx, ok := c.awsContextCollection[KMSContext]; ok {
+		return (ctx.(*kms.KMSCloudContext)).DecryptDataKey(ciphertext, encryptionContext)
+	}
+	return nil, errors.New("can't find the available context to kms resource")
+}
+
+// SignWithKMS assina a mensagem com a chave assimétrica do KMS
+func (c *CloudContextObject) SignWithKMS(keyID string, message []byte, algorithm string) ([]byte, error) {
+	if ctx, ok := c.awsContextCollection[KMSContext]; ok {
+		return (ctx.(*kms.KMSCloudContext)).Sign(keyID, message, algorithm)
+	}
+	return nil, errors.New("can't find the available context to kms resource")
+}
+
+// VerifyWithKMS confere a assinatura da mensagem com a chave assimétrica do KMS
+func (c *CloudContextObject) VerifyWithKMS(keyID string, message, signature []byte, algorithm string) (bool, error) {
+	if ctx, ok := c.awsContextCollection[KMSContext]; ok {
+		return (ctx.(*kms.KMSCloudContext)).Verify(keyID, message, signature, algorithm)
+	}
+	return false, errors.New("can't find the available context to kms resource")
+}
+
+// ConfigureKMSDataKeyCache define validade e limite de usos das chaves de dados em cache; ttl zero desabilita o cache
+func (c *CloudContextObject) ConfigureKMSDataKeyCache(ttl time.Duration, maxUses int) error {
+	if ctx, ok := c.awsContextCollection[KMSContext]; ok {
+		(ctx.(*kms.KMSCloudContext)).ConfigureCache(ttl, maxUses)
+		return nil
+	}
+	return errors.New("can't find the available context to kms resource")
+}
+
+// GetDynamoDBItem lê o item com a chave informada e o converte para out; retorna false quando o item não existe
+func (c *CloudContextObject) GetDynamoDBItem(tableName string, key interface{}, out interface{}, opts ...DynamoDBReadOption) (bool, error) {
+	if ctx, ok := c.awsContextCollection[DynamoDBContext]; ok {
+		return (ctx.(*dynamodb.DynamoDBCloudContext)).GetItem(tableName, key, out, opts...)
+	}
+	return false, errors.New("can't find the available context to dynamodb resource")
+}
+
+// PutDynamoDBItem grava o item, opcionalmente condicionado a uma expressão
+func (c *CloudContextObject) PutDynamoDBItem(tableName string, item interface{}, opts ...DynamoDBWriteOption) error {
+	if ctx, ok := c.awsContextCollection[DynamoDBContext]; ok {
+		return (ctx.(*dynamodb.DynamoDBCloudContext)).PutItem(tableName, item, opts...)
+	}
+	return errors.New("can't find the available context to dynamodb resource")
+}
+
+// QueryDynamoDB consulta os itens que satisfazem a condição de chave, buscando as páginas sob demanda
+func (c *CloudContextObject) QueryDynamoDB(tableName, keyCondition string, values map[string]interface{}, opts ...DynamoDBReadOption) (*DynamoDBQueryIterator, error) {
+	if ctx, ok := c.awsContextCollection[DynamoDBContext]; ok {
+		return (ctx.(*dynamodb.DynamoDBCloudContext)).Query(tableName, keyCondition, values, opts...)
+	}
+	return nil, errors.New("can't find the available context to dynamodb resource")
+}
+
+// BatchGetDynamoDBItems lê os itens das chaves informadas e os converte para o slice apontado por out
+func (c *CloudContextObject) BatchGetDynamoDBItems(tableName string, keys []interface{}, out interface{}, opts ...DynamoDBReadOption) error {
+	if ctx, ok := c.awsContextCollection[DynamoDBContext]; ok {
+		return (ctx.(*dynamodb.DynamoDBCloudContext)).BatchGetItem(tableName, keys, out, opts...)
+	}
+	return errors.New("can't find the available context to dynamodb resource")
+}
+
+// SendSQSMessage envia uma mensagem para a fila e retorna o MessageId atribuído pelo SQS
+func (c *CloudContextObject) SendSQSMessage(queueURL string, message SQSMessage) (string, error) {
+	if ctx, ok := c.awsContextCollection[SQSContext]; ok {
+		return (ctx.(*sqs.SQSCloudContext)).Send(queueURL, message)
+	}
+	return "", errors.New("can't find the available context to sqs resource")
+}
+
+// SendSQSMessageBatch envia as mensagens em lotes de até 10 entradas e 256 KB
+func (c *CloudContextObject) SendSQSMessageBatch(queueURL string, messages []SQSMessage) (*SQSBatchResult, error) {
+	if ctx, ok := c.awsContextCollection[SQSContext]; ok {
+		return (ctx.(*sqs.SQSCloudContext)).SendBatch(queueURL, messages)
+	}
+	return nil, errors.New("can't find the available context to sqs resource")
+}
+
+// ConsumeSQS consome a fila até ctx ser cancelado, entregando as mensagens ao handler
+func (c *CloudContextObject) ConsumeSQS(ctx context.Context, queueURL string, handler SQSHandler, opts ...SQSConsumerOption) error {
+	if sqsCtx, ok := c.awsContextCollection[SQSContext]; ok {
+		return (sqsCtx.(*sqs.SQSCloudContext)).Consume(ctx, queueURL, handler, opts...)
+	}
+	return errors.New("can't find the available context to sqs resource")
+}
+
+// PublishSNSMessage publica uma mensagem no tópico e retorna o MessageId atribuído pelo SNS
+func (c *CloudContextObject) PublishSNSMessage(topicARN string, message SNSMessage) (string, error) {
+	if ctx, ok := c.awsContextCollection[SNSContext]; ok {
+		return (ctx.(*sns.SNSCloudContext)).Publish(topicARN, message)
+	}
+	return "", errors.New("can't find the available context to sns resource")
+}
+
+// PublishSNSMessageBatch publica as mensagens em lotes de até 10 entradas e 256 KB
+func (c *CloudContextObject) PublishSNSMessageBatch(topicARN string, messages []SNSMessage) (*SNSBatchResult, error) {
+	if ctx, ok := c.awsContextCollection[SNSContext]; ok {
+		return (ctx.(*sns.SNSCloudContext)).PublishBatch(topicARN, messages)
+	}
+	return nil, errors.New("can't find the available context to sns resource")
+}
+
+// PutEventBridgeEvents publica os eventos em lotes, repetindo somente as entradas que falharem;
+// os EventIds seguem a ordem dos eventos e as falhas restantes são informadas em um *EventBridgePutEventsError
+func (c *CloudContextObject) PutEventBridgeEvents(events []EventBridgeEvent, opts ...EventBridgePutOption) ([]string, error) {
+	if ctx, ok := c.awsContextCollection[EventBridgeContext]; ok {
+		return (ctx.(*eventbridge.EventBridgeCloudContext)).PutEvents(events, opts...)
+	}
+	return nil, errors.New("can't find the available context to eventbridge resource")
+}
+
+// NewKinesisProducer cria um StreamProducer para o stream do Kinesis Data Streams informado
+func (c *CloudContextObject) NewKinesisProducer(streamName string, opts ...StreamProducerOption) (*StreamProducer, error) {
+	if ctx, ok := c.awsContextCollection[KinesisContext]; ok {
+		return (ctx.(*kinesis.KinesisCloudContext)).NewStreamProducer(streamName, opts...)
+	}
+	return nil, errors.New("can't find the available context to kinesis resource")
+}
+
+// NewFirehoseProducer cria um StreamProducer para o delivery stream do Firehose informado
+func (c *CloudContextObject) NewFirehoseProducer(deliveryStreamName string, opts ...StreamProducerOption) (*StreamProducer, error) {
+	if ctx, ok := c.awsContextCollection[KinesisContext]; ok {
+		return (ctx.(*kinesis.KinesisCloudContext)).NewFirehoseProducer(deliveryStreamName, opts...)
+	}
+	return nil, errors.New("can't find the available context to kinesis resource")
+}
+
+// InvokeLambdaRaw invoca a função com o payload informado, sem conversão de requisição e resposta
+func (c *CloudContextObject) InvokeLambdaRaw(functionName string, payload []byte, opts ...LambdaInvokeOption) (*LambdaInvokeResult, error) {
+	if ctx, ok := c.awsContextCollection[LambdaContext]; ok {
+		return (ctx.(*lambda.LambdaCloudContext)).InvokeRaw(functionName, payload, opts...)
+	}
+	return nil, errors.New("can't find the available context to lambda resource")
+}
+
+// GetAppConfigValue obtém a configuração mais recente do perfil, mantida em cache pelo intervalo de consulta do AppConfig
+func (c *CloudContextObject) GetAppConfigValue(application, environment, profile string) (interface{}, error) {
+	if ctx, ok := c.awsContextCollection[AppConfigContext]; ok {
+		return (ctx.(*appconfig.AppConfigCloudContext)).GetValue(application, environment, profile)
+	}
+	return nil, errors.New("can't find the available context to appconfig resource")
+}
+
+// GetAppConfigFlags retorna o avaliador de feature flags do perfil informado
+func (c *CloudContextObject) GetAppConfigFlags(application, environment, profile string) (*AppConfigFlags, error) {
+	if ctx, ok := c.awsContextCollection[AppConfigContext]; ok {
+		return (ctx.(*appconfig.AppConfigCloudContext)).Flags(application, environment, profile), nil
+	}
+	return nil, errors.New("can't find the available context to appconfig resource")
+}
+
+// GetAppConfigLastError retorna o erro da última consulta do perfil, ou nil se ela teve sucesso
+func (c *CloudContextObject) GetAppConfigLastError(application, environment, profile string) error {
+	if ctx, ok := c.awsContextCollection[AppConfigContext]; ok {
+		return (ctx.(*appconfig.AppConfigCloudContext)).LastError(application, environment, profile)
+	}
+	return errors.New("can't find the available context to appconfig resource")
+}
+
+// WhoAmI obtém a conta e o principal das credenciais do contexto, usando a sessão do contexto
+func (c *CloudContextObject) WhoAmI() (*CallerIdentity, error) {
+	return sts.NewSTSContext(c.awsSession).WhoAmI()
+}
+
+// AssumeRole cria um contexto derivado que atua com as credenciais do papel informado, renovadas
+// automaticamente, e com os mesmos recursos habilitados. Configurações feitas no contexto original,
+// como criptografia de objetos S3 e o token gerenciado, não são copiadas
+func (c *CloudContextObject) AssumeRole(roleArn string, opts ...AssumeRoleOption) (CloudContext, error) {
+	sess, err := sts.AssumeRoleSession(c.awsSession, roleArn, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make(CloudContextList, 0, len(c.awsContextCollection))
+	for res := range c.awsContextCollection {
+		resources = append(resources, res)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i] < resources[j] })
+
+	cloudContext, err := newCloudContext(sess, resources)
+	if err != nil {
+		return nil, err
+	}
+	return cloudContext, nil
+}
+
+// GetRDSDSN gera o DSN do Postgres ou MySQL a partir do segredo do RDS lido pelo SecretsManagerContext
+func (c *CloudContextObject) GetRDSDSN(secretName string, opts ...RDSOption) (string, error) {
+	return c.newRDSProvider(secretName, opts).DSN()
+}
+
+// NewRDSConnector cria um driver.Connector, para uso com sql.OpenDB, que gera o DSN a partir do segredo
+// do RDS e lê o segredo novamente quando a conexão falha por autenticação, como após a rotação da senha
+func (c *CloudContextObject) NewRDSConnector(secretName string, drv driver.Driver, opts ...RDSOption) (driver.Connector, error) {
+	if _, ok := c.awsContextCollection[SecretsManagerContext]; !ok {
+		return nil, errors.New("can't find the available context to secrets manager resource")
+	}
+	return rds.NewConnector(c.newRDSProvider(secretName, opts), drv), nil
+}
+
+func (c *CloudContextObject) newRDSProvider(secretName string, opts []RDSOption) *rds.Provider {
+	load := func() ([]byte, error) {
+		value, err := c.GetSecretValue(secretName, JSONSecret)
+		if err != nil {
+			return nil, err
+		}
+		raw, ok := value.([]byte)
+		if !ok {
+			return nil, errors.New("unexpected rds secret value type")
+		}
+		return raw, nil
+	}
+	return rds.NewProvider(load, c.awsSession.Config.Credentials, aws.StringValue(c.awsSession.Config.Region), opts...)
+}
+
 func (c *CloudContextObject) NewAutoManagedToken(url, clientId, clientSecret string, certSkipVerify bool) {
 	c.managedToken = auth.NewAutoManagedToken(
 		url,
@@ -0,0 +1,27 @@
+package cloud
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCloudContext(t *testing.T) {
+	sess := session.Must(session.NewSession(&aws.Config{Region: aws.String("us-east-1")}))
+
+	t.Run("Create the requested resources", func(t *testing.T) {
+		cloudContext, err := newCloudContext(sess, CloudContextList{S3Context, SQSContext, AppConfigContext})
+
+		assert.NoError(t, err)
+		assert.Len(t, cloudContext.awsContextCollection, 3)
+		assert.Contains(t, cloudContext.awsContextCollection, SQSContext)
+	})
+
+	t.Run("Unknown resource", func(t *testing.T) {
+		_, err := newCloudContext(sess, CloudContextList{ContextType(-1)})
+
+		assert.Error(t, err)
+	})
+}